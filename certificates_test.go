@@ -0,0 +1,229 @@
+/*
+Copyright 2026 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package function
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+
+	"github.com/crossplane/function-sdk-go/logging"
+)
+
+func TestCertificateReloaderReload(t *testing.T) {
+	type want struct {
+		changed bool
+		err     error
+		serial  int64
+	}
+
+	cases := map[string]struct {
+		reason string
+		// Serial numbers of the certificates to write before each reload. A
+		// zero serial writes an invalid certificate. Repeating a serial
+		// reloads without rewriting the certificates.
+		serials []int64
+		want    want
+	}{
+		"InitialLoad": {
+			reason:  "The first reload should load the certificates.",
+			serials: []int64{1},
+			want:    want{changed: true, serial: 1},
+		},
+		"Unchanged": {
+			reason:  "Reloading unchanged certificates should report no change.",
+			serials: []int64{1, 1},
+			want:    want{changed: false, serial: 1},
+		},
+		"Rotated": {
+			reason:  "Reloading rotated certificates should serve the new certificates.",
+			serials: []int64{1, 2},
+			want:    want{changed: true, serial: 2},
+		},
+		"InvalidAfterValid": {
+			reason:  "An invalid reload should return an error and keep serving the last valid certificates.",
+			serials: []int64{1, 0},
+			want:    want{err: cmpopts.AnyError, serial: 1},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			dir := t.TempDir()
+			r := newCertificateReloader(dir, time.Minute)
+
+			var changed bool
+			var err error
+			for i, serial := range tc.serials {
+				if i == 0 || serial != tc.serials[i-1] {
+					writeCertificates(t, dir, serial)
+				}
+				changed, err = r.Reload()
+			}
+
+			if diff := cmp.Diff(tc.want.changed, changed); diff != "" {
+				t.Errorf("\n%s\nr.Reload(): -want changed, +got changed:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.err, err, cmpopts.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nr.Reload(): -want err, +got err:\n%s", tc.reason, diff)
+			}
+
+			cfg, _ := r.GetConfigForClient(nil)
+			leaf, perr := x509.ParseCertificate(cfg.Certificates[0].Certificate[0])
+			if perr != nil {
+				t.Fatalf("cannot parse served certificate: %v", perr)
+			}
+			if diff := cmp.Diff(tc.want.serial, leaf.SerialNumber.Int64()); diff != "" {
+				t.Errorf("\n%s\nr.GetConfigForClient(): -want serial, +got serial:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestReloadingMTLSCertificatesHandshake(t *testing.T) {
+	dir := t.TempDir()
+	writeCertificates(t, dir, 1)
+
+	so := &ServeOptions{}
+	if err := ReloadingMTLSCertificates(dir, 10*time.Millisecond)(so); err != nil {
+		t.Fatalf("ReloadingMTLSCertificates(...): %v", err)
+	}
+
+	lis, err := tls.Listen("tcp", "127.0.0.1:0", &tls.Config{
+		MinVersion:         tls.VersionTLS12,
+		ClientAuth:         tls.RequireAndVerifyClientCert,
+		GetConfigForClient: so.certificates.GetConfigForClient,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer lis.Close()
+	go func() {
+		for {
+			conn, err := lis.Accept()
+			if err != nil {
+				return
+			}
+			_ = conn.(*tls.Conn).Handshake() //nolint:forcetypeassert // tls.Listen always returns a *tls.Conn.
+			_ = conn.Close()
+		}
+	}()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go so.certificates.Run(ctx, logging.NewNopLogger())
+
+	if diff := cmp.Diff(int64(1), handshake(t, lis.Addr().String(), dir)); diff != "" {
+		t.Errorf("handshake(...): -want serial, +got serial:\n%s", diff)
+	}
+
+	// The client presents the rotated certificate, which is only trusted if
+	// the server reloaded the rotated CA certificate too.
+	writeCertificates(t, dir, 2)
+
+	deadline := time.Now().Add(5 * time.Second)
+	for handshake(t, lis.Addr().String(), dir) != 2 {
+		if time.Now().After(deadline) {
+			t.Fatal("handshake(...): server didn't present the rotated certificate")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+// handshake performs a TLS handshake with the server at addr, presenting the
+// client certificate in dir. It returns the serial number of the server's
+// certificate, or zero if the handshake failed.
+func handshake(t *testing.T, addr, dir string) int64 {
+	t.Helper()
+
+	crt, err := tls.LoadX509KeyPair(filepath.Join(dir, "tls.crt"), filepath.Join(dir, "tls.key"))
+	if err != nil {
+		// The certificates may be partially written.
+		return 0
+	}
+	d := &tls.Dialer{Config: &tls.Config{
+		MinVersion:   tls.VersionTLS12,
+		Certificates: []tls.Certificate{crt},
+		// The test certificates have no subject alternative names, so we
+		// can't verify them. We only want to know which one was presented.
+		InsecureSkipVerify: true, //nolint:gosec // See above.
+	}}
+	conn, err := d.DialContext(context.Background(), "tcp", addr)
+	if err != nil {
+		return 0
+	}
+	defer conn.Close()
+	return conn.(*tls.Conn).ConnectionState().PeerCertificates[0].SerialNumber.Int64() //nolint:forcetypeassert // tls.Dialer always returns a *tls.Conn.
+}
+
+// writeCertificates writes a self-signed certificate with the supplied serial
+// number to dir as tls.crt, tls.key, and ca.crt. A zero serial writes garbage.
+func writeCertificates(t *testing.T, dir string, serial int64) {
+	t.Helper()
+
+	if serial == 0 {
+		for _, f := range []string{"tls.crt", "tls.key", "ca.crt"} {
+			if err := os.WriteFile(filepath.Join(dir, f), []byte("garbage"), 0o600); err != nil {
+				t.Fatal(err)
+			}
+		}
+		return
+	}
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tmpl := &x509.Certificate{
+		SerialNumber:          big.NewInt(serial),
+		Subject:               pkix.Name{CommonName: "function"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	kder, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	crt := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+	k := pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: kder})
+	for f, b := range map[string][]byte{"tls.crt": crt, "tls.key": k, "ca.crt": crt} {
+		if err := os.WriteFile(filepath.Join(dir, f), b, 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
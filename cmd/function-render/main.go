@@ -0,0 +1,339 @@
+/*
+Copyright 2026 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Command function-render runs a composition function against local YAML
+// manifests, and prints the desired state, results, and conditions it returns.
+//
+// The Function must be running, and serving insecure gRPC connections:
+//
+//	go run . --insecure --debug
+//	function-render -address localhost:9443 -xr xr.yaml -input input.yaml
+//
+// Use package render to run a Function in-process instead.
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/types/known/structpb"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	kyaml "k8s.io/apimachinery/pkg/util/yaml"
+	"sigs.k8s.io/yaml"
+
+	"github.com/crossplane/function-sdk-go/errors"
+	v1 "github.com/crossplane/function-sdk-go/proto/v1"
+	"github.com/crossplane/function-sdk-go/render"
+)
+
+// AnnotationKeyCompositionResourceName is the annotation Crossplane uses to
+// record the name of a composed resource.
+const AnnotationKeyCompositionResourceName = "crossplane.io/composition-resource-name"
+
+// Tag sent with each request.
+const tag = "function-render"
+
+// paths is a flag that may be specified multiple times.
+type paths []string
+
+func (p *paths) String() string { return strings.Join(*p, ",") }
+
+func (p *paths) Set(v string) error {
+	*p = append(*p, v)
+	return nil
+}
+
+// config is the command's configuration.
+type config struct {
+	address       string
+	timeout       time.Duration
+	maxIterations int
+
+	xr          string
+	input       string
+	context     string
+	observed    paths
+	required    paths
+	crds        paths
+	credentials paths
+}
+
+func main() {
+	if err := run(context.Background(), os.Args[1:], os.Stdout); err != nil {
+		fmt.Fprintf(os.Stderr, "function-render: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+func run(ctx context.Context, args []string, stdout io.Writer) error {
+	c := &config{}
+	fs := flag.NewFlagSet("function-render", flag.ContinueOnError)
+	fs.StringVar(&c.address, "address", "localhost:9443", "Address of the Function's insecure gRPC server.")
+	fs.DurationVar(&c.timeout, "timeout", time.Minute, "How long to wait for the Function to render.")
+	fs.IntVar(&c.maxIterations, "max-iterations", render.DefaultMaxIterations, "How many times to call the Function while waiting for its requirements to stabilize.")
+	fs.StringVar(&c.xr, "xr", "", "File containing the observed composite resource (XR). Required.")
+	fs.StringVar(&c.input, "input", "", "File containing the Function's input, from the Composition's pipeline step.")
+	fs.StringVar(&c.context, "context", "", "File containing the pipeline context, as a YAML object.")
+	fs.Var(&c.observed, "observed", "File containing observed composed resources, each annotated with "+AnnotationKeyCompositionResourceName+". May be repeated.")
+	fs.Var(&c.required, "required", "File containing resources used to satisfy the Function's resource requirements. May be repeated.")
+	fs.Var(&c.crds, "crds", "File containing CRDs used to satisfy the Function's schema requirements. May be repeated.")
+	fs.Var(&c.credentials, "credentials", "File containing Secrets to pass to the Function as credentials, named after each Secret. May be repeated.")
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return nil
+		}
+		return err
+	}
+	if c.xr == "" {
+		return errors.New("the -xr flag is required")
+	}
+
+	req, err := buildRequest(c)
+	if err != nil {
+		return err
+	}
+	store, err := buildStore(c)
+	if err != nil {
+		return err
+	}
+
+	conn, err := grpc.NewClient(c.address, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		return errors.Wrapf(err, "cannot connect to Function at %q", c.address)
+	}
+	defer conn.Close() //nolint:errcheck // Nothing useful to do with this error.
+
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	out, err := render.NewRenderer(render.Remote(conn), render.WithStore(store), render.WithMaxIterations(c.maxIterations)).Render(ctx, req)
+	if err != nil {
+		return err
+	}
+
+	y, err := marshalOutput(out.Response)
+	if err != nil {
+		return err
+	}
+	_, err = stdout.Write(y)
+	return errors.Wrap(err, "cannot write output")
+}
+
+// buildRequest builds a RunFunctionRequest from the configured files.
+func buildRequest(c *config) (*v1.RunFunctionRequest, error) {
+	req := &v1.RunFunctionRequest{
+		Meta: &v1.RequestMeta{
+			Tag: tag,
+			Capabilities: []v1.Capability{
+				v1.Capability_CAPABILITY_CAPABILITIES,
+				v1.Capability_CAPABILITY_REQUIRED_RESOURCES,
+				v1.Capability_CAPABILITY_CREDENTIALS,
+				v1.Capability_CAPABILITY_CONDITIONS,
+				v1.Capability_CAPABILITY_REQUIRED_SCHEMAS,
+			},
+		},
+		Observed: &v1.State{},
+		Desired:  &v1.State{},
+	}
+
+	xr, err := readObject(c.xr)
+	if err != nil {
+		return nil, err
+	}
+	req.Observed.Composite = &v1.Resource{Resource: xr}
+
+	if c.input != "" {
+		if req.Input, err = readObject(c.input); err != nil {
+			return nil, err
+		}
+	}
+
+	if c.context != "" {
+		if req.Context, err = readObject(c.context); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, path := range c.observed {
+		ocds, err := readObjects(path)
+		if err != nil {
+			return nil, err
+		}
+		for _, ocd := range ocds {
+			name := metadata(ocd, "annotations").GetStructValue().GetFields()[AnnotationKeyCompositionResourceName].GetStringValue()
+			if name == "" {
+				return nil, errors.Errorf("observed resource %q in %q must have the %s annotation", metadata(ocd, "name").GetStringValue(), path, AnnotationKeyCompositionResourceName)
+			}
+			if _, ok := req.Observed.Resources[name]; ok {
+				return nil, errors.Errorf("observed resource %q in %q has the same %s annotation as another observed resource: %q", metadata(ocd, "name").GetStringValue(), path, AnnotationKeyCompositionResourceName, name)
+			}
+			if req.Observed.Resources == nil {
+				req.Observed.Resources = map[string]*v1.Resource{}
+			}
+			req.Observed.Resources[name] = &v1.Resource{Resource: ocd}
+		}
+	}
+
+	for _, path := range c.credentials {
+		secrets, err := readObjects(path)
+		if err != nil {
+			return nil, err
+		}
+		for _, s := range secrets {
+			name, data, err := secretData(s)
+			if err != nil {
+				return nil, errors.Wrapf(err, "cannot load credentials from %q", path)
+			}
+			if req.Credentials == nil {
+				req.Credentials = map[string]*v1.Credentials{}
+			}
+			req.Credentials[name] = &v1.Credentials{
+				Source: &v1.Credentials_CredentialData{CredentialData: &v1.CredentialData{Data: data}},
+			}
+		}
+	}
+
+	return req, nil
+}
+
+// buildStore builds a Store from the configured files.
+func buildStore(c *config) (*render.MemoryStore, error) {
+	s := &render.MemoryStore{Schemas: map[schema.GroupVersionKind]*structpb.Struct{}}
+	for _, path := range c.required {
+		rs, err := readObjects(path)
+		if err != nil {
+			return nil, err
+		}
+		s.Resources = append(s.Resources, rs...)
+	}
+	for _, path := range c.crds {
+		crds, err := readObjects(path)
+		if err != nil {
+			return nil, err
+		}
+		for _, crd := range crds {
+			addSchemas(s.Schemas, crd)
+		}
+	}
+	return s, nil
+}
+
+// addSchemas adds the OpenAPI v3 schema of each version of the supplied CRD.
+func addSchemas(schemas map[schema.GroupVersionKind]*structpb.Struct, crd *structpb.Struct) {
+	spec := crd.GetFields()["spec"].GetStructValue().GetFields()
+	group := spec["group"].GetStringValue()
+	kind := spec["names"].GetStructValue().GetFields()["kind"].GetStringValue()
+	for _, v := range spec["versions"].GetListValue().GetValues() {
+		vf := v.GetStructValue().GetFields()
+		sc := vf["schema"].GetStructValue().GetFields()["openAPIV3Schema"].GetStructValue()
+		if sc == nil {
+			continue
+		}
+		schemas[schema.GroupVersionKind{Group: group, Version: vf["name"].GetStringValue(), Kind: kind}] = sc
+	}
+}
+
+// secretData returns the name and decoded data of the supplied Secret.
+func secretData(s *structpb.Struct) (string, map[string][]byte, error) {
+	name := metadata(s, "name").GetStringValue()
+	if s.GetFields()["kind"].GetStringValue() != "Secret" {
+		return "", nil, errors.Errorf("%q is not a Secret", name)
+	}
+	data := map[string][]byte{}
+	for k, v := range s.GetFields()["data"].GetStructValue().GetFields() {
+		b, err := base64.StdEncoding.DecodeString(v.GetStringValue())
+		if err != nil {
+			return "", nil, errors.Wrapf(err, "cannot decode key %q of Secret %q", k, name)
+		}
+		data[k] = b
+	}
+	for k, v := range s.GetFields()["stringData"].GetStructValue().GetFields() {
+		data[k] = []byte(v.GetStringValue())
+	}
+	return name, data, nil
+}
+
+// readObject reads a file that must contain exactly one YAML object.
+func readObject(path string) (*structpb.Struct, error) {
+	objs, err := readObjects(path)
+	if err != nil {
+		return nil, err
+	}
+	if len(objs) != 1 {
+		return nil, errors.Errorf("%q must contain exactly one YAML object, found %d", path, len(objs))
+	}
+	return objs[0], nil
+}
+
+// readObjects reads all YAML objects from a file that may contain several
+// YAML documents.
+func readObjects(path string) ([]*structpb.Struct, error) {
+	b, err := os.ReadFile(path) //nolint:gosec // Reading user-supplied files is intended.
+	if err != nil {
+		return nil, errors.Wrapf(err, "cannot read %q", path)
+	}
+
+	var out []*structpb.Struct
+	d := kyaml.NewYAMLOrJSONDecoder(bytes.NewReader(b), 4096)
+	for {
+		obj := map[string]any{}
+		err := d.Decode(&obj)
+		if errors.Is(err, io.EOF) {
+			return out, nil
+		}
+		if err != nil {
+			return nil, errors.Wrapf(err, "cannot decode YAML in %q", path)
+		}
+		if len(obj) == 0 {
+			continue
+		}
+		s, err := structpb.NewStruct(obj)
+		if err != nil {
+			return nil, errors.Wrapf(err, "cannot convert object in %q", path)
+		}
+		out = append(out, s)
+	}
+}
+
+// marshalOutput returns the desired state, results, and conditions of the
+// supplied response as YAML.
+func marshalOutput(rsp *v1.RunFunctionResponse) ([]byte, error) {
+	out := &v1.RunFunctionResponse{
+		Desired:    rsp.GetDesired(),
+		Results:    rsp.GetResults(),
+		Conditions: rsp.GetConditions(),
+	}
+	j, err := protojson.Marshal(out)
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot marshal response to JSON")
+	}
+	y, err := yaml.JSONToYAML(j)
+	return y, errors.Wrap(err, "cannot convert response to YAML")
+}
+
+func metadata(o *structpb.Struct, field string) *structpb.Value {
+	return o.GetFields()["metadata"].GetStructValue().GetFields()[field]
+}
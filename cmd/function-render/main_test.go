@@ -0,0 +1,222 @@
+/*
+Copyright 2026 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/structpb"
+
+	v1 "github.com/crossplane/function-sdk-go/proto/v1"
+)
+
+// EchoFunction requires ConfigMaps labelled app=cool, and composes a copy of
+// each one it receives. It also reports its input and credentials.
+type EchoFunction struct {
+	v1.UnimplementedFunctionRunnerServiceServer
+}
+
+func (f *EchoFunction) RunFunction(_ context.Context, req *v1.RunFunctionRequest) (*v1.RunFunctionResponse, error) {
+	rsp := &v1.RunFunctionResponse{
+		Desired: &v1.State{Resources: map[string]*v1.Resource{}},
+		Requirements: &v1.Requirements{
+			Resources: map[string]*v1.ResourceSelector{
+				"cms": {ApiVersion: "v1", Kind: "ConfigMap", Match: &v1.ResourceSelector_MatchLabels{MatchLabels: &v1.MatchLabels{Labels: map[string]string{"app": "cool"}}}},
+			},
+		},
+	}
+	for i, r := range req.GetRequiredResources()["cms"].GetItems() {
+		rsp.Desired.Resources[fmt.Sprintf("cm-%d", i)] = &v1.Resource{Resource: r.GetResource()}
+	}
+	msg := fmt.Sprintf("xr=%s input=%s observed=%d password=%s",
+		req.GetObserved().GetComposite().GetResource().GetFields()["kind"].GetStringValue(),
+		req.GetInput().GetFields()["value"].GetStringValue(),
+		len(req.GetObserved().GetResources()),
+		req.GetCredentials()["creds"].GetCredentialData().GetData()["password"],
+	)
+	rsp.Results = []*v1.Result{{Severity: v1.Severity_SEVERITY_NORMAL, Message: msg}}
+	rsp.Conditions = []*v1.Condition{{Type: "Rendered", Status: v1.Status_STATUS_CONDITION_TRUE}}
+	rsp.Context = &structpb.Struct{Fields: map[string]*structpb.Value{"ignored": structpb.NewBoolValue(true)}}
+	return rsp, nil
+}
+
+func TestRun(t *testing.T) {
+	lc := &net.ListenConfig{}
+	lis, err := lc.Listen(context.Background(), "tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	srv := grpc.NewServer()
+	v1.RegisterFunctionRunnerServiceServer(srv, &EchoFunction{})
+	go srv.Serve(lis) //nolint:errcheck // We don't care about serve errors.
+	defer srv.Stop()
+
+	dir := t.TempDir()
+	write := func(name, content string) string {
+		t.Helper()
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+		return path
+	}
+
+	xr := write("xr.yaml", `
+apiVersion: example.org/v1
+kind: XR
+metadata:
+  name: cool-xr
+`)
+	input := write("input.yaml", `
+apiVersion: example.org/v1
+kind: Input
+value: hello
+`)
+	observed := write("observed.yaml", `
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: observed
+  annotations:
+    crossplane.io/composition-resource-name: observed
+`)
+	required := write("required.yaml", `
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: b
+  namespace: default
+  labels:
+    app: cool
+---
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: a
+  namespace: default
+  labels:
+    app: cool
+---
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: c
+  namespace: default
+  labels:
+    app: uncool
+`)
+	creds := write("creds.yaml", `
+apiVersion: v1
+kind: Secret
+metadata:
+  name: creds
+data:
+  password: aHVudGVyMg==
+`)
+
+	got := &bytes.Buffer{}
+	err = run(context.Background(), []string{
+		"-address", lis.Addr().String(),
+		"-xr", xr,
+		"-input", input,
+		"-observed", observed,
+		"-required", required,
+		"-credentials", creds,
+	}, got)
+	if err != nil {
+		t.Fatalf("run(...): %v", err)
+	}
+
+	want := `conditions:
+- status: STATUS_CONDITION_TRUE
+  type: Rendered
+desired:
+  resources:
+    cm-0:
+      resource:
+        apiVersion: v1
+        kind: ConfigMap
+        metadata:
+          labels:
+            app: cool
+          name: a
+          namespace: default
+    cm-1:
+      resource:
+        apiVersion: v1
+        kind: ConfigMap
+        metadata:
+          labels:
+            app: cool
+          name: b
+          namespace: default
+results:
+- message: xr=XR input=hello observed=1 password=hunter2
+  severity: SEVERITY_NORMAL
+`
+	if diff := cmp.Diff(want, got.String()); diff != "" {
+		t.Errorf("run(...): -want output, +got output:\n%s", diff)
+	}
+}
+
+func TestRunMissingXR(t *testing.T) {
+	if err := run(context.Background(), []string{"-address", "localhost:1"}, &bytes.Buffer{}); err == nil {
+		t.Errorf("run(...): want error when -xr is not specified, got nil")
+	}
+}
+
+func TestRunDuplicateObserved(t *testing.T) {
+	dir := t.TempDir()
+	xr := filepath.Join(dir, "xr.yaml")
+	if err := os.WriteFile(xr, []byte("apiVersion: example.org/v1\nkind: XR\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	observed := filepath.Join(dir, "observed.yaml")
+	if err := os.WriteFile(observed, []byte(`
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: a
+  annotations:
+    crossplane.io/composition-resource-name: cm
+---
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: b
+  annotations:
+    crossplane.io/composition-resource-name: cm
+`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	// The Function isn't running, so we'd also get an error if run tried to
+	// call it. Make sure we got the error we expected instead.
+	err := run(context.Background(), []string{"-address", "localhost:1", "-xr", xr, "-observed", observed}, &bytes.Buffer{})
+	if err == nil || !strings.Contains(err.Error(), "same "+AnnotationKeyCompositionResourceName) {
+		t.Errorf("run(...): want error when observed resources have the same composition resource name, got %v", err)
+	}
+}
@@ -0,0 +1,136 @@
+/*
+Copyright 2026 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package functiontest
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"google.golang.org/protobuf/testing/protocmp"
+
+	v1 "github.com/crossplane/function-sdk-go/proto/v1"
+)
+
+// AssertDesiredComposite asserts that the response's desired composite
+// resource matches the supplied YAML.
+func AssertDesiredComposite(t testing.TB, rsp *v1.RunFunctionResponse, y string) {
+	t.Helper()
+	want := StructFromYAML(t, y).AsMap()
+	got := rsp.GetDesired().GetComposite().GetResource().AsMap()
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("desired composite resource: -want, +got:\n%s", diff)
+	}
+}
+
+// AssertDesiredResource asserts that the response includes the named desired
+// composed resource, and that it matches the supplied YAML. Other desired
+// composed resources are ignored.
+func AssertDesiredResource(t testing.TB, rsp *v1.RunFunctionResponse, name, y string) {
+	t.Helper()
+	r, ok := rsp.GetDesired().GetResources()[name]
+	if !ok {
+		t.Errorf("desired composed resource %q: not found in response", name)
+		return
+	}
+	want := StructFromYAML(t, y).AsMap()
+	if diff := cmp.Diff(want, r.GetResource().AsMap()); diff != "" {
+		t.Errorf("desired composed resource %q: -want, +got:\n%s", name, diff)
+	}
+}
+
+// AssertDesiredResources asserts that the response includes exactly the
+// supplied desired composed resources, keyed by name, each described as YAML.
+func AssertDesiredResources(t testing.TB, rsp *v1.RunFunctionResponse, want map[string]string) {
+	t.Helper()
+	w := make(map[string]map[string]any, len(want))
+	for name, y := range want {
+		w[name] = StructFromYAML(t, y).AsMap()
+	}
+	got := make(map[string]map[string]any, len(rsp.GetDesired().GetResources()))
+	for name, r := range rsp.GetDesired().GetResources() {
+		got[name] = r.GetResource().AsMap()
+	}
+	if diff := cmp.Diff(w, got); diff != "" {
+		t.Errorf("desired composed resources: -want, +got:\n%s", diff)
+	}
+}
+
+// AssertReady asserts that the named desired composed resource has the
+// supplied readiness.
+func AssertReady(t testing.TB, rsp *v1.RunFunctionResponse, name string, want v1.Ready) {
+	t.Helper()
+	r, ok := rsp.GetDesired().GetResources()[name]
+	if !ok {
+		t.Errorf("desired composed resource %q: not found in response", name)
+		return
+	}
+	if diff := cmp.Diff(want, r.GetReady()); diff != "" {
+		t.Errorf("desired composed resource %q readiness: -want, +got:\n%s", name, diff)
+	}
+}
+
+// AssertResults asserts that the response includes exactly the supplied
+// results, in order.
+func AssertResults(t testing.TB, rsp *v1.RunFunctionResponse, want ...*v1.Result) {
+	t.Helper()
+	if diff := cmp.Diff(want, rsp.GetResults(), protocmp.Transform(), cmpopts.EquateEmpty()); diff != "" {
+		t.Errorf("results: -want, +got:\n%s", diff)
+	}
+}
+
+// AssertConditions asserts that the response includes exactly the supplied
+// conditions, in order.
+func AssertConditions(t testing.TB, rsp *v1.RunFunctionResponse, want ...*v1.Condition) {
+	t.Helper()
+	if diff := cmp.Diff(want, rsp.GetConditions(), protocmp.Transform(), cmpopts.EquateEmpty()); diff != "" {
+		t.Errorf("conditions: -want, +got:\n%s", diff)
+	}
+}
+
+// AssertContextValue asserts that the supplied key of the response's context
+// matches the supplied YAML value.
+func AssertContextValue(t testing.TB, rsp *v1.RunFunctionResponse, key, y string) {
+	t.Helper()
+	got, ok := rsp.GetContext().GetFields()[key]
+	if !ok {
+		t.Errorf("context key %q: not found in response", key)
+		return
+	}
+	want := ValueFromYAML(t, y).AsInterface()
+	if diff := cmp.Diff(want, got.AsInterface()); diff != "" {
+		t.Errorf("context key %q: -want, +got:\n%s", key, diff)
+	}
+}
+
+// AssertNoContextValue asserts that the response's context doesn't include the
+// supplied key.
+func AssertNoContextValue(t testing.TB, rsp *v1.RunFunctionResponse, key string) {
+	t.Helper()
+	if v, ok := rsp.GetContext().GetFields()[key]; ok {
+		t.Errorf("context key %q: want no value, got %v", key, v.AsInterface())
+	}
+}
+
+// AssertRequirements asserts that the response's requirements match the
+// supplied requirements.
+func AssertRequirements(t testing.TB, rsp *v1.RunFunctionResponse, want *v1.Requirements) {
+	t.Helper()
+	if diff := cmp.Diff(want, rsp.GetRequirements(), protocmp.Transform()); diff != "" {
+		t.Errorf("requirements: -want, +got:\n%s", diff)
+	}
+}
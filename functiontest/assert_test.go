@@ -0,0 +1,157 @@
+/*
+Copyright 2026 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package functiontest
+
+import (
+	"fmt"
+	"runtime"
+	"testing"
+
+	v1 "github.com/crossplane/function-sdk-go/proto/v1"
+)
+
+// RecordingT records the errors reported by an assertion.
+type RecordingT struct {
+	testing.TB
+
+	errors []string
+}
+
+func (t *RecordingT) Helper() {}
+
+func (t *RecordingT) Errorf(format string, args ...any) {
+	t.errors = append(t.errors, fmt.Sprintf(format, args...))
+}
+
+// Fatalf records the error and stops the calling goroutine, so it must be
+// called from a goroutine started by the test.
+func (t *RecordingT) Fatalf(format string, args ...any) {
+	t.errors = append(t.errors, fmt.Sprintf(format, args...))
+	runtime.Goexit()
+}
+
+func TestAssertions(t *testing.T) {
+	rsp := &v1.RunFunctionResponse{
+		Desired: &v1.State{
+			Composite: &v1.Resource{Resource: StructFromYAML(t, `{"apiVersion": "example.org/v1", "kind": "XR"}`)},
+			Resources: map[string]*v1.Resource{
+				"cm": {
+					Resource: StructFromYAML(t, `{"apiVersion": "v1", "kind": "ConfigMap"}`),
+					Ready:    v1.Ready_READY_TRUE,
+				},
+			},
+		},
+		Context: StructFromYAML(t, `{"example.org/key": ["a", "b"]}`),
+	}
+
+	cases := map[string]struct {
+		reason string
+		assert func(t testing.TB)
+		fail   bool
+	}{
+		"DesiredCompositeMatches": {
+			reason: "AssertDesiredComposite should pass when the composite resource matches.",
+			assert: func(t testing.TB) {
+				AssertDesiredComposite(t, rsp, "apiVersion: example.org/v1\nkind: XR")
+			},
+		},
+		"DesiredCompositeDiffers": {
+			reason: "AssertDesiredComposite should fail when the composite resource differs.",
+			assert: func(t testing.TB) {
+				AssertDesiredComposite(t, rsp, "apiVersion: example.org/v1\nkind: YR")
+			},
+			fail: true,
+		},
+		"DesiredResourceMatches": {
+			reason: "AssertDesiredResource should pass when the named resource matches.",
+			assert: func(t testing.TB) {
+				AssertDesiredResource(t, rsp, "cm", "apiVersion: v1\nkind: ConfigMap")
+			},
+		},
+		"DesiredResourceMissing": {
+			reason: "AssertDesiredResource should fail when the named resource is missing.",
+			assert: func(t testing.TB) {
+				AssertDesiredResource(t, rsp, "secret", "apiVersion: v1\nkind: Secret")
+			},
+			fail: true,
+		},
+		"DesiredResourcesExtra": {
+			reason: "AssertDesiredResources should fail when the response has resources that weren't expected.",
+			assert: func(t testing.TB) {
+				AssertDesiredResources(t, rsp, map[string]string{})
+			},
+			fail: true,
+		},
+		"ReadyDiffers": {
+			reason: "AssertReady should fail when the named resource's readiness differs.",
+			assert: func(t testing.TB) {
+				AssertReady(t, rsp, "cm", v1.Ready_READY_FALSE)
+			},
+			fail: true,
+		},
+		"NoResults": {
+			reason: "AssertResults should pass when no results are expected and none are returned.",
+			assert: func(t testing.TB) {
+				AssertResults(t, rsp)
+			},
+		},
+		"UnexpectedCondition": {
+			reason: "AssertConditions should fail when an expected condition isn't returned.",
+			assert: func(t testing.TB) {
+				AssertConditions(t, rsp, &v1.Condition{Type: "Ready"})
+			},
+			fail: true,
+		},
+		"ContextValueMatches": {
+			reason: "AssertContextValue should pass when the context value matches.",
+			assert: func(t testing.TB) {
+				AssertContextValue(t, rsp, "example.org/key", "[a, b]")
+			},
+		},
+		"ContextValueMissing": {
+			reason: "AssertContextValue should fail when the context key is missing.",
+			assert: func(t testing.TB) {
+				AssertContextValue(t, rsp, "example.org/other", "a")
+			},
+			fail: true,
+		},
+		"NoContextValue": {
+			reason: "AssertNoContextValue should fail when the context key is present.",
+			assert: func(t testing.TB) {
+				AssertNoContextValue(t, rsp, "example.org/key")
+			},
+			fail: true,
+		},
+		"NoRequirements": {
+			reason: "AssertRequirements should pass when no requirements are expected and none are returned.",
+			assert: func(t testing.TB) {
+				AssertRequirements(t, rsp, nil)
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			rt := &RecordingT{TB: t}
+			tc.assert(rt)
+
+			if failed := len(rt.errors) > 0; failed != tc.fail {
+				t.Errorf("\n%s\nwant failed %t, got failed %t: %v", tc.reason, tc.fail, failed, rt.errors)
+			}
+		})
+	}
+}
@@ -0,0 +1,147 @@
+/*
+Copyright 2026 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package functiontest
+
+import (
+	"context"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"sigs.k8s.io/yaml"
+
+	"github.com/crossplane/function-sdk-go/errors"
+)
+
+// Golden file suffixes.
+const (
+	// SuffixRequest is the suffix of a request fixture.
+	SuffixRequest = ".yaml"
+
+	// SuffixGolden is the suffix of a golden response file.
+	SuffixGolden = ".golden.yaml"
+)
+
+// EnvUpdateGolden is the environment variable that makes RunGolden update
+// golden files instead of comparing responses to them, for example:
+//
+//	UPDATE_GOLDEN=true go test ./...
+const EnvUpdateGolden = "UPDATE_GOLDEN"
+
+// RunGolden sends each request fixture in the supplied directory to the
+// supplied Server, and compares the Function's response to a golden file. A
+// request fixture is a file ending in .yaml that describes a
+// RunFunctionRequest, as accepted by RequestFromYAML. Its golden file has the
+// same name, ending in .golden.yaml instead, and describes the expected
+// RunFunctionResponse.
+//
+// Each fixture runs as a subtest named after the fixture's file. Set the
+// UPDATE_GOLDEN environment variable to true to write the Function's responses
+// to the golden files rather than comparing them.
+func RunGolden(t *testing.T, srv *Server, dir string) {
+	t.Helper()
+
+	update, _ := strconv.ParseBool(os.Getenv(EnvUpdateGolden))
+
+	fixtures, err := requestFixtures(dir)
+	if err != nil {
+		t.Fatalf("cannot find request fixtures: %v", err)
+	}
+	if len(fixtures) == 0 {
+		t.Fatalf("no request fixtures ending in %s found in %q", SuffixRequest, dir)
+	}
+
+	for _, path := range fixtures {
+		name := strings.TrimSuffix(filepath.Base(path), SuffixRequest)
+		t.Run(name, func(t *testing.T) {
+			y, err := os.ReadFile(path) //nolint:gosec // Reading test fixtures is intended.
+			if err != nil {
+				t.Fatalf("cannot read request fixture: %v", err)
+			}
+
+			rsp, err := srv.RunFunction(context.Background(), RequestFromYAML(t, string(y)))
+			if err != nil {
+				t.Fatalf("RunFunction(...): %v", err)
+			}
+
+			got, err := MarshalYAML(rsp)
+			if err != nil {
+				t.Fatalf("cannot marshal response to YAML: %v", err)
+			}
+
+			golden := filepath.Join(dir, name+SuffixGolden)
+			if update {
+				if err := os.WriteFile(golden, got, 0o600); err != nil {
+					t.Fatalf("cannot update golden file: %v", err)
+				}
+				return
+			}
+
+			want, err := os.ReadFile(golden) //nolint:gosec // Reading golden files is intended.
+			if errors.Is(err, fs.ErrNotExist) {
+				t.Fatalf("golden file %q doesn't exist - run go test with %s=true to create it", golden, EnvUpdateGolden)
+			}
+			if err != nil {
+				t.Fatalf("cannot read golden file: %v", err)
+			}
+
+			if diff := cmp.Diff(string(want), string(got)); diff != "" {
+				t.Errorf("%s: -want golden, +got response:\n%s", golden, diff)
+			}
+		})
+	}
+}
+
+// MarshalYAML returns a stable YAML representation of the supplied message.
+// Fields use their protobuf JSON names. Object keys, including map-derived
+// fields like desired resources and context keys, are sorted.
+func MarshalYAML(m proto.Message) ([]byte, error) {
+	j, err := protojson.Marshal(m)
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot marshal message to JSON")
+	}
+	// JSONToYAML unmarshals the JSON into a map, and marshals the map with
+	// sorted keys. This also discards protojson's unstable whitespace.
+	y, err := yaml.JSONToYAML(j)
+	return y, errors.Wrap(err, "cannot convert JSON to YAML")
+}
+
+// requestFixtures returns the paths of the request fixtures in dir, in order.
+func requestFixtures(dir string) ([]string, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, errors.Wrapf(err, "cannot read directory %q", dir)
+	}
+
+	paths := make([]string, 0, len(entries))
+	for _, e := range entries {
+		n := e.Name()
+		if e.IsDir() || !strings.HasSuffix(n, SuffixRequest) || strings.HasSuffix(n, SuffixGolden) {
+			continue
+		}
+		paths = append(paths, filepath.Join(dir, n))
+	}
+	sort.Strings(paths)
+	return paths, nil
+}
@@ -0,0 +1,65 @@
+/*
+Copyright 2026 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package functiontest
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	v1 "github.com/crossplane/function-sdk-go/proto/v1"
+)
+
+func TestRunGolden(t *testing.T) {
+	RunGolden(t, Serve(t, &ExampleFunction{}), "testdata/golden")
+}
+
+func TestMarshalYAML(t *testing.T) {
+	rsp := &v1.RunFunctionResponse{
+		Context: StructFromYAML(t, `{"b": 2, "a": 1}`),
+		Desired: &v1.State{
+			Resources: map[string]*v1.Resource{
+				"z": {Resource: StructFromYAML(t, `{"kind": "Z"}`)},
+				"a": {Resource: StructFromYAML(t, `{"kind": "A"}`)},
+			},
+		},
+	}
+
+	want := `context:
+  a: 1
+  b: 2
+desired:
+  resources:
+    a:
+      resource:
+        kind: A
+    z:
+      resource:
+        kind: Z
+`
+
+	// Marshal repeatedly, since map iteration order is random.
+	for range 10 {
+		got, err := MarshalYAML(rsp)
+		if err != nil {
+			t.Fatalf("MarshalYAML(...): %v", err)
+		}
+		if diff := cmp.Diff(want, string(got)); diff != "" {
+			t.Fatalf("MarshalYAML(...): -want, +got:\n%s", diff)
+		}
+	}
+}
@@ -0,0 +1,200 @@
+/*
+Copyright 2026 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package functiontest
+
+import (
+	"testing"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/structpb"
+	"sigs.k8s.io/yaml"
+
+	v1 "github.com/crossplane/function-sdk-go/proto/v1"
+)
+
+// A RequestOption configures a RunFunctionRequest built by NewRequest.
+type RequestOption func(t testing.TB, req *v1.RunFunctionRequest)
+
+// NewRequest returns a RunFunctionRequest configured by the supplied options.
+func NewRequest(t testing.TB, o ...RequestOption) *v1.RunFunctionRequest {
+	t.Helper()
+	req := &v1.RunFunctionRequest{
+		Meta:     &v1.RequestMeta{},
+		Observed: &v1.State{},
+		Desired:  &v1.State{},
+	}
+	for _, fn := range o {
+		fn(t, req)
+	}
+	return req
+}
+
+// WithTag sets the request's tag.
+func WithTag(tag string) RequestOption {
+	return func(_ testing.TB, req *v1.RunFunctionRequest) {
+		req.Meta.Tag = tag
+	}
+}
+
+// WithInput sets the request's input, described as YAML.
+func WithInput(y string) RequestOption {
+	return func(t testing.TB, req *v1.RunFunctionRequest) {
+		t.Helper()
+		req.Input = StructFromYAML(t, y)
+	}
+}
+
+// WithContextValue sets the supplied key of the request's context to the
+// supplied value, described as YAML.
+func WithContextValue(key, y string) RequestOption {
+	return func(t testing.TB, req *v1.RunFunctionRequest) {
+		t.Helper()
+		if req.Context == nil {
+			req.Context = &structpb.Struct{Fields: map[string]*structpb.Value{}}
+		}
+		req.Context.Fields[key] = ValueFromYAML(t, y)
+	}
+}
+
+// WithObservedComposite sets the request's observed composite resource,
+// described as YAML.
+func WithObservedComposite(y string) RequestOption {
+	return func(t testing.TB, req *v1.RunFunctionRequest) {
+		t.Helper()
+		req.Observed.Composite = &v1.Resource{Resource: StructFromYAML(t, y)}
+	}
+}
+
+// WithObservedResource sets the named observed composed resource, described as
+// YAML.
+func WithObservedResource(name, y string) RequestOption {
+	return func(t testing.TB, req *v1.RunFunctionRequest) {
+		t.Helper()
+		if req.Observed.Resources == nil {
+			req.Observed.Resources = map[string]*v1.Resource{}
+		}
+		req.Observed.Resources[name] = &v1.Resource{Resource: StructFromYAML(t, y)}
+	}
+}
+
+// WithDesiredComposite sets the request's desired composite resource,
+// described as YAML.
+func WithDesiredComposite(y string) RequestOption {
+	return func(t testing.TB, req *v1.RunFunctionRequest) {
+		t.Helper()
+		req.Desired.Composite = &v1.Resource{Resource: StructFromYAML(t, y)}
+	}
+}
+
+// WithDesiredResource sets the named desired composed resource, described as
+// YAML.
+func WithDesiredResource(name, y string) RequestOption {
+	return func(t testing.TB, req *v1.RunFunctionRequest) {
+		t.Helper()
+		if req.Desired.Resources == nil {
+			req.Desired.Resources = map[string]*v1.Resource{}
+		}
+		req.Desired.Resources[name] = &v1.Resource{Resource: StructFromYAML(t, y)}
+	}
+}
+
+// WithRequiredResources sets the resources that satisfy the named resource
+// requirement, each described as YAML.
+func WithRequiredResources(name string, ys ...string) RequestOption {
+	return func(t testing.TB, req *v1.RunFunctionRequest) {
+		t.Helper()
+		if req.RequiredResources == nil {
+			req.RequiredResources = map[string]*v1.Resources{}
+		}
+		rs := &v1.Resources{}
+		for _, y := range ys {
+			rs.Items = append(rs.Items, &v1.Resource{Resource: StructFromYAML(t, y)})
+		}
+		req.RequiredResources[name] = rs
+	}
+}
+
+// WithCredentials sets the named credentials to the supplied data.
+func WithCredentials(name string, data map[string][]byte) RequestOption {
+	return func(_ testing.TB, req *v1.RunFunctionRequest) {
+		if req.Credentials == nil {
+			req.Credentials = map[string]*v1.Credentials{}
+		}
+		req.Credentials[name] = &v1.Credentials{
+			Source: &v1.Credentials_CredentialData{CredentialData: &v1.CredentialData{Data: data}},
+		}
+	}
+}
+
+// WithCapabilities sets the capabilities advertised by the request.
+func WithCapabilities(c ...v1.Capability) RequestOption {
+	return func(_ testing.TB, req *v1.RunFunctionRequest) {
+		req.Meta.Capabilities = c
+	}
+}
+
+// RequestFromYAML returns the RunFunctionRequest described by the supplied
+// YAML. The YAML uses the request's protobuf JSON field names, for example:
+//
+//	observed:
+//	  composite:
+//	    resource:
+//	      apiVersion: example.org/v1
+//	      kind: XR
+func RequestFromYAML(t testing.TB, y string) *v1.RunFunctionRequest {
+	t.Helper()
+	req := &v1.RunFunctionRequest{}
+	unmarshalYAML(t, y, req)
+	return req
+}
+
+// ResponseFromYAML returns the RunFunctionResponse described by the supplied
+// YAML. The YAML uses the response's protobuf JSON field names.
+func ResponseFromYAML(t testing.TB, y string) *v1.RunFunctionResponse {
+	t.Helper()
+	rsp := &v1.RunFunctionResponse{}
+	unmarshalYAML(t, y, rsp)
+	return rsp
+}
+
+// StructFromYAML returns the supplied YAML object as a struct.
+func StructFromYAML(t testing.TB, y string) *structpb.Struct {
+	t.Helper()
+	s := &structpb.Struct{}
+	unmarshalYAML(t, y, s)
+	return s
+}
+
+// ValueFromYAML returns the supplied YAML value as a struct value.
+func ValueFromYAML(t testing.TB, y string) *structpb.Value {
+	t.Helper()
+	v := &structpb.Value{}
+	unmarshalYAML(t, y, v)
+	return v
+}
+
+func unmarshalYAML(t testing.TB, y string, m proto.Message) {
+	t.Helper()
+	j, err := yaml.YAMLToJSON([]byte(y))
+	if err != nil {
+		t.Fatalf("cannot convert YAML to JSON: %v", err)
+	}
+	if err := protojson.Unmarshal(j, m); err != nil {
+		t.Fatalf("cannot unmarshal JSON into %T: %v", m, err)
+	}
+}
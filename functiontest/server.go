@@ -0,0 +1,122 @@
+/*
+Copyright 2026 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package functiontest helps test composition functions. It serves a Function
+// in-process, over an in-memory gRPC connection, using the same interceptor
+// chain as function.Serve. Tests describe requests using YAML, and check
+// responses using assertions that print readable diffs.
+package functiontest
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/test/bufconn"
+
+	function "github.com/crossplane/function-sdk-go"
+	v1 "github.com/crossplane/function-sdk-go/proto/v1"
+	"github.com/crossplane/function-sdk-go/render"
+)
+
+// bufferSize of the in-memory connection to the Function.
+const bufferSize = 1024 * 1024
+
+// A Server serves a Function over an in-memory gRPC connection.
+type Server struct {
+	client v1.FunctionRunnerServiceClient
+}
+
+// Serve the supplied Function over an in-memory gRPC connection until the
+// test ends. The Function is served by function.ServeContext, with insecure
+// credentials and the metrics server disabled. The supplied options are
+// applied after these defaults, so they can enable other features of the
+// interceptor chain like recovery, request timeouts, and tracing.
+func Serve(t testing.TB, fn v1.FunctionRunnerServiceServer, o ...function.ServeOption) *Server {
+	t.Helper()
+
+	lis := bufconn.Listen(bufferSize)
+
+	so := []function.ServeOption{function.Insecure(true), function.WithMetricsServer("")}
+	so = append(so, o...)
+	so = append(so, function.WithListener(lis))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() {
+		err := function.ServeContext(ctx, fn, so...)
+		// ServeContext closes the listener when it stops serving, but not
+		// if it fails to start. Closing it ensures clients don't block
+		// waiting for a server that will never accept their connection.
+		_ = lis.Close()
+		done <- err
+	}()
+
+	conn, err := grpc.NewClient("passthrough:///bufconn",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return lis.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		cancel()
+		t.Fatalf("cannot create gRPC client for in-memory connection: %v", err)
+	}
+
+	t.Cleanup(func() {
+		_ = conn.Close()
+		cancel()
+		if err := <-done; err != nil {
+			t.Errorf("function.ServeContext(...): %v", err)
+		}
+	})
+
+	return &Server{client: v1.NewFunctionRunnerServiceClient(conn)}
+}
+
+// RunFunction sends the supplied request to the Function and returns its
+// response.
+func (s *Server) RunFunction(ctx context.Context, req *v1.RunFunctionRequest) (*v1.RunFunctionResponse, error) {
+	return s.client.RunFunction(ctx, req)
+}
+
+// Run sends the supplied request to the Function and returns its response. It
+// fails the test immediately if the Function returns an error.
+func (s *Server) Run(t testing.TB, req *v1.RunFunctionRequest) *v1.RunFunctionResponse {
+	t.Helper()
+	rsp, err := s.RunFunction(context.Background(), req)
+	if err != nil {
+		t.Fatalf("RunFunction(...): %v", err)
+	}
+	return rsp
+}
+
+// RunWithRequirements sends the supplied request to the Function, and
+// satisfies any requirements it returns from the supplied Store, like
+// Crossplane does. It calls the Function again with the required resources
+// and schemas until the Function's requirements stop changing, and returns its
+// final response. It fails the test immediately if the Function returns an
+// error, or if its requirements don't converge within the maximum number of
+// iterations. Use NewStore to build a Store.
+func (s *Server) RunWithRequirements(t testing.TB, req *v1.RunFunctionRequest, st render.Store, o ...render.RendererOption) *v1.RunFunctionResponse {
+	t.Helper()
+	o = append([]render.RendererOption{render.WithStore(st)}, o...)
+	out, err := render.NewRenderer(s, o...).Render(context.Background(), req)
+	if err != nil {
+		t.Fatalf("Render(...): %v", err)
+	}
+	return out.Response
+}
@@ -0,0 +1,167 @@
+/*
+Copyright 2026 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package functiontest
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/testing/protocmp"
+	"google.golang.org/protobuf/types/known/structpb"
+
+	function "github.com/crossplane/function-sdk-go"
+	v1 "github.com/crossplane/function-sdk-go/proto/v1"
+	"github.com/crossplane/function-sdk-go/request"
+	"github.com/crossplane/function-sdk-go/resource"
+	"github.com/crossplane/function-sdk-go/resource/composed"
+	"github.com/crossplane/function-sdk-go/response"
+)
+
+// ExampleFunction composes a ConfigMap named after the XR, and records the
+// XR's name in its context.
+type ExampleFunction struct {
+	v1.UnimplementedFunctionRunnerServiceServer
+}
+
+func (f *ExampleFunction) RunFunction(_ context.Context, req *v1.RunFunctionRequest) (*v1.RunFunctionResponse, error) {
+	rsp := response.To(req, response.DefaultTTL)
+
+	xr, err := request.GetObservedCompositeResource(req)
+	if err != nil {
+		response.Fatal(rsp, err)
+		return rsp, nil
+	}
+
+	cm := composed.New()
+	cm.SetAPIVersion("v1")
+	cm.SetKind("ConfigMap")
+	cm.SetName(xr.Resource.GetName())
+
+	desired, err := request.GetDesiredComposedResources(req)
+	if err != nil {
+		response.Fatal(rsp, err)
+		return rsp, nil
+	}
+	desired["configmap"] = &resource.DesiredComposed{Resource: cm, Ready: resource.ReadyTrue}
+	if err := response.SetDesiredComposedResources(rsp, desired); err != nil {
+		response.Fatal(rsp, err)
+		return rsp, nil
+	}
+
+	response.SetContextKey(rsp, "example.org/name", structpb.NewStringValue(xr.Resource.GetName()))
+	response.RequireSchema(rsp, "xr", "example.org/v1", "XR")
+	response.Normal(rsp, "composed a ConfigMap")
+	response.ConditionTrue(rsp, "Composed", "Success")
+	return rsp, nil
+}
+
+// PanickingFunction panics on every request.
+type PanickingFunction struct {
+	v1.UnimplementedFunctionRunnerServiceServer
+}
+
+func (f *PanickingFunction) RunFunction(_ context.Context, _ *v1.RunFunctionRequest) (*v1.RunFunctionResponse, error) {
+	panic("boom")
+}
+
+func TestServe(t *testing.T) {
+	srv := Serve(t, &ExampleFunction{})
+
+	req := NewRequest(t,
+		WithTag("hello"),
+		WithObservedComposite(`
+apiVersion: example.org/v1
+kind: XR
+metadata:
+  name: cool-xr
+`),
+		WithDesiredResource("existing", `
+apiVersion: example.org/v1
+kind: Composed
+`),
+	)
+
+	rsp := srv.Run(t, req)
+
+	AssertDesiredResources(t, rsp, map[string]string{
+		"existing": `
+apiVersion: example.org/v1
+kind: Composed
+`,
+		"configmap": `
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: cool-xr
+`,
+	})
+	AssertReady(t, rsp, "configmap", v1.Ready_READY_TRUE)
+	AssertContextValue(t, rsp, "example.org/name", "cool-xr")
+	AssertResults(t, rsp, &v1.Result{
+		Severity: v1.Severity_SEVERITY_NORMAL,
+		Message:  "composed a ConfigMap",
+		Target:   v1.Target_TARGET_COMPOSITE.Enum(),
+	})
+	AssertConditions(t, rsp, &v1.Condition{
+		Type:   "Composed",
+		Status: v1.Status_STATUS_CONDITION_TRUE,
+		Reason: "Success",
+		Target: v1.Target_TARGET_COMPOSITE.Enum(),
+	})
+	AssertRequirements(t, rsp, &v1.Requirements{
+		Schemas: map[string]*v1.SchemaSelector{
+			"xr": {ApiVersion: "example.org/v1", Kind: "XR"},
+		},
+	})
+}
+
+func TestServeInterceptorChain(t *testing.T) {
+	srv := Serve(t, &PanickingFunction{}, function.WithRecovery())
+
+	_, err := srv.RunFunction(context.Background(), NewRequest(t))
+	if got := status.Code(err); got != codes.Internal {
+		t.Errorf("RunFunction(...): want status code %s from recovery interceptor, got %s: %v", codes.Internal, got, err)
+	}
+}
+
+func TestRequestFromYAML(t *testing.T) {
+	got := RequestFromYAML(t, `
+meta:
+  tag: hello
+observed:
+  composite:
+    resource:
+      apiVersion: example.org/v1
+      kind: XR
+desired: {}
+`)
+
+	want := NewRequest(t,
+		WithTag("hello"),
+		WithObservedComposite(`
+apiVersion: example.org/v1
+kind: XR
+`),
+	)
+
+	if diff := cmp.Diff(want, got, protocmp.Transform()); diff != "" {
+		t.Errorf("RequestFromYAML(...): -want, +got:\n%s", diff)
+	}
+}
@@ -0,0 +1,63 @@
+/*
+Copyright 2026 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package functiontest
+
+import (
+	"testing"
+
+	"google.golang.org/protobuf/types/known/structpb"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/crossplane/function-sdk-go/render"
+)
+
+// A StoreOption configures a Store built by NewStore.
+type StoreOption func(t testing.TB, s *render.MemoryStore)
+
+// NewStore returns an in-memory Store that satisfies a Function's resource
+// and schema requirements. Use it with Server.RunWithRequirements.
+func NewStore(t testing.TB, o ...StoreOption) *render.MemoryStore {
+	t.Helper()
+	s := &render.MemoryStore{Schemas: map[schema.GroupVersionKind]*structpb.Struct{}}
+	for _, fn := range o {
+		fn(t, s)
+	}
+	return s
+}
+
+// WithObjects adds the supplied objects, each described as YAML, to the Store.
+func WithObjects(ys ...string) StoreOption {
+	return func(t testing.TB, s *render.MemoryStore) {
+		t.Helper()
+		for _, y := range ys {
+			s.Resources = append(s.Resources, StructFromYAML(t, y))
+		}
+	}
+}
+
+// WithSchema adds the OpenAPI v3 schema of the supplied kind, described as
+// YAML, to the Store.
+func WithSchema(apiVersion, kind, y string) StoreOption {
+	return func(t testing.TB, s *render.MemoryStore) {
+		t.Helper()
+		gv, err := schema.ParseGroupVersion(apiVersion)
+		if err != nil {
+			t.Fatalf("cannot parse apiVersion %q: %v", apiVersion, err)
+		}
+		s.Schemas[gv.WithKind(kind)] = StructFromYAML(t, y)
+	}
+}
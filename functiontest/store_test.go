@@ -0,0 +1,128 @@
+/*
+Copyright 2026 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package functiontest
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"k8s.io/utils/ptr"
+
+	v1 "github.com/crossplane/function-sdk-go/proto/v1"
+	"github.com/crossplane/function-sdk-go/render"
+)
+
+// RequiringFunction requires ConfigMaps labelled app=cool in the default
+// namespace, and the schema of XRs. It reports what it received.
+type RequiringFunction struct {
+	v1.UnimplementedFunctionRunnerServiceServer
+}
+
+func (f *RequiringFunction) RunFunction(_ context.Context, req *v1.RunFunctionRequest) (*v1.RunFunctionResponse, error) {
+	names := []string{}
+	for _, r := range req.GetRequiredResources()["cms"].GetItems() {
+		names = append(names, r.GetResource().GetFields()["metadata"].GetStructValue().GetFields()["name"].GetStringValue())
+	}
+	typ := req.GetRequiredSchemas()["xr"].GetOpenapiV3().GetFields()["type"].GetStringValue()
+
+	return &v1.RunFunctionResponse{
+		Results: []*v1.Result{{Severity: v1.Severity_SEVERITY_NORMAL, Message: fmt.Sprintf("cms=%v schema=%s", names, typ)}},
+		Requirements: &v1.Requirements{
+			Resources: map[string]*v1.ResourceSelector{
+				"cms": {
+					ApiVersion: "v1",
+					Kind:       "ConfigMap",
+					Match:      &v1.ResourceSelector_MatchLabels{MatchLabels: &v1.MatchLabels{Labels: map[string]string{"app": "cool"}}},
+					Namespace:  ptr.To("default"),
+				},
+			},
+			Schemas: map[string]*v1.SchemaSelector{
+				"xr": {ApiVersion: "example.org/v1", Kind: "XR"},
+			},
+		},
+	}, nil
+}
+
+// DivergingFunction requires a different resource every time it's called.
+type DivergingFunction struct {
+	v1.UnimplementedFunctionRunnerServiceServer
+
+	calls int
+}
+
+func (f *DivergingFunction) RunFunction(_ context.Context, _ *v1.RunFunctionRequest) (*v1.RunFunctionResponse, error) {
+	f.calls++
+	return &v1.RunFunctionResponse{
+		Requirements: &v1.Requirements{
+			Resources: map[string]*v1.ResourceSelector{
+				"cm": {ApiVersion: "v1", Kind: "ConfigMap", Match: &v1.ResourceSelector_MatchName{MatchName: fmt.Sprintf("cm-%d", f.calls)}},
+			},
+		},
+	}, nil
+}
+
+func TestRunWithRequirements(t *testing.T) {
+	st := NewStore(t,
+		WithObjects(`
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: match
+  namespace: default
+  labels:
+    app: cool
+`, `
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: wrong-namespace
+  namespace: other
+  labels:
+    app: cool
+`, `
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: wrong-labels
+  namespace: default
+  labels:
+    app: uncool
+`),
+		WithSchema("example.org/v1", "XR", `type: object`),
+	)
+
+	rsp := Serve(t, &RequiringFunction{}).RunWithRequirements(t, NewRequest(t), st)
+
+	AssertResults(t, rsp, &v1.Result{Severity: v1.Severity_SEVERITY_NORMAL, Message: "cms=[match] schema=object"})
+}
+
+func TestRunWithRequirementsNeverConverge(t *testing.T) {
+	srv := Serve(t, &DivergingFunction{})
+
+	rt := &RecordingT{TB: t}
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		srv.RunWithRequirements(rt, NewRequest(t), NewStore(t), render.WithMaxIterations(3))
+	}()
+	<-done
+
+	if len(rt.errors) == 0 {
+		t.Errorf("RunWithRequirements(...): want test failure when requirements never converge, got none")
+	}
+}
@@ -0,0 +1,183 @@
+/*
+Copyright 2026 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package input supports Functions whose input has several versions. Each
+// version of the input is decoded into its own Go type, then converted to a
+// single internal hub version that the Function uses.
+package input
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"google.golang.org/protobuf/types/known/structpb"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/crossplane/function-sdk-go/errors"
+	"github.com/crossplane/function-sdk-go/internal/object"
+	"github.com/crossplane/function-sdk-go/openapi"
+	v1 "github.com/crossplane/function-sdk-go/proto/v1"
+	"github.com/crossplane/function-sdk-go/resource"
+)
+
+// An UnknownInputError is returned when a Function's input has an apiVersion
+// and kind that isn't registered. Its message is suitable for returning to
+// Crossplane using response.Fatal.
+type UnknownInputError struct {
+	// GVK of the supplied input.
+	GVK schema.GroupVersionKind
+
+	// Supported kinds of input, sorted by apiVersion then kind.
+	Supported []schema.GroupVersionKind
+}
+
+// Error returns the error message.
+func (e *UnknownInputError) Error() string {
+	supported := make([]string, len(e.Supported))
+	for i, gvk := range e.Supported {
+		supported[i] = fmt.Sprintf("%s %s", gvk.GroupVersion(), gvk.Kind)
+	}
+	if e.GVK.Empty() {
+		return fmt.Sprintf("function input must specify an apiVersion and kind - supported inputs are: %s", strings.Join(supported, ", "))
+	}
+	return fmt.Sprintf("unsupported function input apiVersion %q and kind %q - supported inputs are: %s", e.GVK.GroupVersion(), e.GVK.Kind, strings.Join(supported, ", "))
+}
+
+// IsUnknownInput returns true if the supplied error indicates a Function's
+// input has an apiVersion and kind that isn't registered.
+func IsUnknownInput(err error) bool {
+	e := &UnknownInputError{}
+	return errors.As(err, &e)
+}
+
+// A version of input that can be converted to hub type H.
+type version[H runtime.Object] struct {
+	// decode the supplied struct and convert it to the hub type.
+	decode func(s *structpb.Struct) (H, error)
+
+	// schema input of this version is validated against, if any.
+	schema *openapi.Schema
+}
+
+// A Registry of the versions of a Function's input. Each version is converted
+// to the hub type H.
+type Registry[H runtime.Object] struct {
+	versions   map[schema.GroupVersionKind]version[H]
+	defaulters []func(H)
+}
+
+// NewRegistry returns a new, empty Registry of input versions that convert to
+// hub type H. Use Register and RegisterHub to add versions.
+func NewRegistry[H runtime.Object]() *Registry[H] {
+	return &Registry[H]{versions: make(map[schema.GroupVersionKind]version[H])}
+}
+
+// Register a version of input with the supplied apiVersion and kind. Input of
+// this version is decoded into a new object of type T, which must be a pointer
+// to a struct, then converted to the hub type using the supplied function.
+// Registering the same apiVersion and kind again replaces the earlier version.
+func Register[T, H runtime.Object](r *Registry[H], gvk schema.GroupVersionKind, convert func(in T) (H, error)) {
+	r.versions[gvk] = version[H]{schema: r.versions[gvk].schema, decode: func(s *structpb.Struct) (H, error) {
+		var zero H
+		in, err := object.New[T]()
+		if err != nil {
+			return zero, err
+		}
+		if err := resource.AsObject(s, in); err != nil {
+			return zero, errors.Wrapf(err, "cannot decode function input %s %s", gvk.GroupVersion(), gvk.Kind)
+		}
+		out, err := convert(in)
+		return out, errors.Wrapf(err, "cannot convert function input %s %s to %T", gvk.GroupVersion(), gvk.Kind, zero)
+	}}
+}
+
+// RegisterHub registers the hub version of input with the supplied apiVersion
+// and kind. Input of this version is decoded directly into the hub type.
+func (r *Registry[H]) RegisterHub(gvk schema.GroupVersionKind) {
+	Register(r, gvk, func(in H) (H, error) { return in, nil })
+}
+
+// SetSchema sets the OpenAPI schema that input with the supplied apiVersion and
+// kind is validated against before it's decoded. Use openapi.FromCRD to load
+// the schema from the Function's input CRD.
+func (r *Registry[H]) SetSchema(gvk schema.GroupVersionKind, s *openapi.Schema) {
+	v := r.versions[gvk]
+	v.schema = s
+	r.versions[gvk] = v
+}
+
+// AddDefaulter adds a function that sets default values. Defaulters are called
+// in the order they were added, after input is converted to the hub type, so
+// they apply to input of every version.
+func (r *Registry[H]) AddDefaulter(fn func(in H)) {
+	r.defaulters = append(r.defaulters, fn)
+}
+
+// Supported returns the apiVersions and kinds of input that are registered,
+// sorted by apiVersion then kind.
+func (r *Registry[H]) Supported() []schema.GroupVersionKind {
+	out := make([]schema.GroupVersionKind, 0, len(r.versions))
+	for gvk, v := range r.versions {
+		if v.decode == nil {
+			continue
+		}
+		out = append(out, gvk)
+	}
+	slices.SortFunc(out, func(a, b schema.GroupVersionKind) int {
+		if c := strings.Compare(a.GroupVersion().String(), b.GroupVersion().String()); c != 0 {
+			return c
+		}
+		return strings.Compare(a.Kind, b.Kind)
+	})
+	return out
+}
+
+// Decode the supplied input. The input is validated against the schema set for
+// its apiVersion and kind, if any. It's then decoded into the type registered
+// for its apiVersion and kind, converted to the hub type, and defaulted. Decode
+// returns an UnknownInputError if the input's apiVersion and kind aren't
+// registered.
+func (r *Registry[H]) Decode(s *structpb.Struct) (H, error) {
+	var zero H
+
+	gvk := schema.FromAPIVersionAndKind(s.GetFields()["apiVersion"].GetStringValue(), s.GetFields()["kind"].GetStringValue())
+	v, ok := r.versions[gvk]
+	if !ok || v.decode == nil {
+		return zero, &UnknownInputError{GVK: gvk, Supported: r.Supported()}
+	}
+
+	if v.schema != nil {
+		if errs := v.schema.Validate(s.AsMap()); len(errs) > 0 {
+			return zero, errors.Wrapf(errs.ToAggregate(), "invalid function input %s %s", gvk.GroupVersion(), gvk.Kind)
+		}
+	}
+
+	out, err := v.decode(s)
+	if err != nil {
+		return zero, err
+	}
+	for _, fn := range r.defaulters {
+		fn(out)
+	}
+	return out, nil
+}
+
+// GetInput decodes the supplied request's input. See Decode.
+func (r *Registry[H]) GetInput(req *v1.RunFunctionRequest) (H, error) {
+	return r.Decode(req.GetInput())
+}
@@ -0,0 +1,248 @@
+/*
+Copyright 2026 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package input
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/crossplane/function-sdk-go/errors"
+	"github.com/crossplane/function-sdk-go/openapi"
+	v1 "github.com/crossplane/function-sdk-go/proto/v1"
+	"github.com/crossplane/function-sdk-go/resource"
+)
+
+var (
+	v1alpha1Input = schema.GroupVersionKind{Group: "example.org", Version: "v1alpha1", Kind: "Input"}
+	v1beta1Input  = schema.GroupVersionKind{Group: "example.org", Version: "v1beta1", Kind: "Input"}
+)
+
+// InputV1Alpha1 is an old version of input, with a single name.
+type InputV1Alpha1 struct {
+	metav1.TypeMeta `json:",inline"`
+
+	Name string `json:"name"`
+}
+
+func (in *InputV1Alpha1) DeepCopyObject() runtime.Object {
+	out := *in
+	return &out
+}
+
+// InputV1Beta1 is the hub version of input, with many names.
+type InputV1Beta1 struct {
+	metav1.TypeMeta `json:",inline"`
+
+	Names  []string `json:"names"`
+	Region string   `json:"region,omitempty"`
+}
+
+func (in *InputV1Beta1) DeepCopyObject() runtime.Object {
+	out := *in
+	out.Names = append([]string(nil), in.Names...)
+	return &out
+}
+
+func NewTestRegistry() *Registry[*InputV1Beta1] {
+	r := NewRegistry[*InputV1Beta1]()
+	r.RegisterHub(v1beta1Input)
+	Register(r, v1alpha1Input, func(in *InputV1Alpha1) (*InputV1Beta1, error) {
+		if in.Name == "" {
+			return nil, errors.New("name is required")
+		}
+		return &InputV1Beta1{
+			TypeMeta: metav1.TypeMeta{APIVersion: v1beta1Input.GroupVersion().String(), Kind: v1beta1Input.Kind},
+			Names:    []string{in.Name},
+		}, nil
+	})
+	r.SetSchema(v1beta1Input, openapi.Must(openapi.FromYAML([]byte(`
+type: object
+properties:
+  names: {type: array, minItems: 1, items: {type: string}}
+  region: {type: string}
+required: [names]
+`))))
+	r.AddDefaulter(func(in *InputV1Beta1) {
+		if in.Region == "" {
+			in.Region = "us-east-1"
+		}
+	})
+	return r
+}
+
+func TestGetInput(t *testing.T) {
+	type want struct {
+		in      *InputV1Beta1
+		err     error
+		unknown *UnknownInputError
+	}
+
+	cases := map[string]struct {
+		reason string
+		req    *v1.RunFunctionRequest
+		want   want
+	}{
+		"Hub": {
+			reason: "Input of the hub version should be decoded and defaulted.",
+			req: &v1.RunFunctionRequest{
+				Input: resource.MustStructJSON(`{"apiVersion":"example.org/v1beta1","kind":"Input","names":["a","b"]}`),
+			},
+			want: want{
+				in: &InputV1Beta1{
+					TypeMeta: metav1.TypeMeta{APIVersion: "example.org/v1beta1", Kind: "Input"},
+					Names:    []string{"a", "b"},
+					Region:   "us-east-1",
+				},
+			},
+		},
+		"HubWithRegion": {
+			reason: "Defaulters shouldn't override values set by the input.",
+			req: &v1.RunFunctionRequest{
+				Input: resource.MustStructJSON(`{"apiVersion":"example.org/v1beta1","kind":"Input","names":["a"],"region":"eu-west-1"}`),
+			},
+			want: want{
+				in: &InputV1Beta1{
+					TypeMeta: metav1.TypeMeta{APIVersion: "example.org/v1beta1", Kind: "Input"},
+					Names:    []string{"a"},
+					Region:   "eu-west-1",
+				},
+			},
+		},
+		"Converted": {
+			reason: "Input of an older version should be converted to the hub version, then defaulted.",
+			req: &v1.RunFunctionRequest{
+				Input: resource.MustStructJSON(`{"apiVersion":"example.org/v1alpha1","kind":"Input","name":"a"}`),
+			},
+			want: want{
+				in: &InputV1Beta1{
+					TypeMeta: metav1.TypeMeta{APIVersion: "example.org/v1beta1", Kind: "Input"},
+					Names:    []string{"a"},
+					Region:   "us-east-1",
+				},
+			},
+		},
+		"ConversionError": {
+			reason: "We should return an error if input can't be converted to the hub version.",
+			req: &v1.RunFunctionRequest{
+				Input: resource.MustStructJSON(`{"apiVersion":"example.org/v1alpha1","kind":"Input"}`),
+			},
+			want: want{
+				err: cmpopts.AnyError,
+			},
+		},
+		"InvalidHub": {
+			reason: "We should return an error if input is invalid according to its version's schema.",
+			req: &v1.RunFunctionRequest{
+				Input: resource.MustStructJSON(`{"apiVersion":"example.org/v1beta1","kind":"Input","names":[]}`),
+			},
+			want: want{
+				err: cmpopts.AnyError,
+			},
+		},
+		"UnknownField": {
+			reason: "We should return an error if input has a field its version doesn't know about.",
+			req: &v1.RunFunctionRequest{
+				Input: resource.MustStructJSON(`{"apiVersion":"example.org/v1alpha1","kind":"Input","names":["a"]}`),
+			},
+			want: want{
+				err: cmpopts.AnyError,
+			},
+		},
+		"UnknownVersion": {
+			reason: "We should return an UnknownInputError if the input's apiVersion isn't registered.",
+			req: &v1.RunFunctionRequest{
+				Input: resource.MustStructJSON(`{"apiVersion":"example.org/v1","kind":"Input"}`),
+			},
+			want: want{
+				err: cmpopts.AnyError,
+				unknown: &UnknownInputError{
+					GVK:       schema.GroupVersionKind{Group: "example.org", Version: "v1", Kind: "Input"},
+					Supported: []schema.GroupVersionKind{v1alpha1Input, v1beta1Input},
+				},
+			},
+		},
+		"NoInput": {
+			reason: "We should return an UnknownInputError if there's no input.",
+			req:    &v1.RunFunctionRequest{},
+			want: want{
+				err: cmpopts.AnyError,
+				unknown: &UnknownInputError{
+					Supported: []schema.GroupVersionKind{v1alpha1Input, v1beta1Input},
+				},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			in, err := NewTestRegistry().GetInput(tc.req)
+
+			if diff := cmp.Diff(tc.want.in, in); diff != "" {
+				t.Errorf("\n%s\nGetInput(...): -want, +got:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.err, err, cmpopts.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nGetInput(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+
+			var unknown *UnknownInputError
+			_ = errors.As(err, &unknown)
+			if diff := cmp.Diff(tc.want.unknown, unknown); diff != "" {
+				t.Errorf("\n%s\nGetInput(...): -want UnknownInputError, +got UnknownInputError:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestUnknownInputError(t *testing.T) {
+	cases := map[string]struct {
+		reason string
+		err    error
+		want   string
+	}{
+		"UnknownVersion": {
+			reason: "The error should name the supplied and supported inputs.",
+			err: &UnknownInputError{
+				GVK:       schema.GroupVersionKind{Group: "example.org", Version: "v1", Kind: "Input"},
+				Supported: []schema.GroupVersionKind{v1alpha1Input, v1beta1Input},
+			},
+			want: `unsupported function input apiVersion "example.org/v1" and kind "Input" - supported inputs are: example.org/v1alpha1 Input, example.org/v1beta1 Input`,
+		},
+		"NoInput": {
+			reason: "The error should explain that input must have an apiVersion and kind.",
+			err: &UnknownInputError{
+				Supported: []schema.GroupVersionKind{v1beta1Input},
+			},
+			want: "function input must specify an apiVersion and kind - supported inputs are: example.org/v1beta1 Input",
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			if diff := cmp.Diff(tc.want, tc.err.Error()); diff != "" {
+				t.Errorf("\n%s\nError(): -want, +got:\n%s", tc.reason, diff)
+			}
+			if !IsUnknownInput(errors.Wrap(tc.err, "cannot get input")) {
+				t.Errorf("\n%s\nIsUnknownInput(...): want true, got false", tc.reason)
+			}
+		})
+	}
+}
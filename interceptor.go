@@ -0,0 +1,189 @@
+/*
+Copyright 2026 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package function
+
+import (
+	"context"
+	"runtime/debug"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/structpb"
+
+	"github.com/crossplane/function-sdk-go/logging"
+	v1 "github.com/crossplane/function-sdk-go/proto/v1"
+	"github.com/crossplane/function-sdk-go/proto/v1beta1"
+	"github.com/crossplane/function-sdk-go/redact"
+)
+
+// WithRecovery configures the Function to recover from any panic while
+// handling a request. The panic and its stack trace are logged using the
+// Logger supplied via WithLogger, or to stderr if no Logger was supplied, and
+// the request fails with gRPC status code Internal. Without this option a
+// panic crashes the Function's process.
+func WithRecovery() ServeOption {
+	return func(o *ServeOptions) error {
+		o.Recovery = true
+		return nil
+	}
+}
+
+// WithRequestTimeout configures the Function to set a deadline on the context
+// passed to RunFunction. The deadline is the supplied timeout from when the
+// request is received, or the deadline set by the caller if that is earlier.
+// It includes any time the request spends waiting for WithConcurrencyLimit.
+// The Function's RunFunction implementation must respect context cancellation
+// for the timeout to take effect. A zero timeout disables the deadline.
+func WithRequestTimeout(d time.Duration) ServeOption {
+	return func(o *ServeOptions) error {
+		if d < 0 {
+			return errors.New("request timeout must not be negative")
+		}
+		o.RequestTimeout = d
+		return nil
+	}
+}
+
+// isRunFunction returns true if the supplied gRPC method is a RunFunction call,
+// as opposed to e.g. a health check or reflection call.
+func isRunFunction(method string) bool {
+	return method == v1.FunctionRunnerService_RunFunction_FullMethodName ||
+		method == v1beta1.FunctionRunnerService_RunFunction_FullMethodName
+}
+
+// requestMeta returns the tag and observed composite resource of the supplied
+// RunFunctionRequest, which may be v1 or v1beta1. It returns false if req
+// isn't a RunFunctionRequest.
+func requestMeta(req any) (string, *structpb.Struct, bool) {
+	switch r := req.(type) {
+	case *v1.RunFunctionRequest:
+		return r.GetMeta().GetTag(), r.GetObserved().GetComposite().GetResource(), true
+	case *v1beta1.RunFunctionRequest:
+		return r.GetMeta().GetTag(), r.GetObserved().GetComposite().GetResource(), true
+	}
+	return "", nil, false
+}
+
+// loggingInterceptor returns an interceptor that adds a Logger to the context
+// passed to RunFunction. The Logger is derived from the supplied Logger, and
+// includes details of the request. Use logging.FromContext to retrieve it. The
+// interceptor also logs each request and response at debug level, with their
+// secrets redacted.
+func loggingInterceptor(log logging.Logger) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		tag, xr, ok := requestMeta(req)
+		if !isRunFunction(info.FullMethod) || !ok {
+			return handler(ctx, req)
+		}
+
+		f := xr.GetFields()
+		md := f["metadata"].GetStructValue().GetFields()
+		kv := []any{
+			logging.KeyTag, tag,
+			logging.KeyRequestID, uuid.NewString(),
+			logging.KeyXRAPIVersion, f["apiVersion"].GetStringValue(),
+			logging.KeyXRKind, f["kind"].GetStringValue(),
+			logging.KeyXRName, md["name"].GetStringValue(),
+		}
+		if ns := md["namespace"].GetStringValue(); ns != "" {
+			kv = append(kv, logging.KeyXRNamespace, ns)
+		}
+
+		log := log.WithValues(kv...)
+		log.Debug("Running Function", "request", redacted{req})
+
+		rsp, err := handler(logging.NewContext(ctx, log), req)
+		if err != nil {
+			log.Debug("Function returned an error", "error", err)
+			return rsp, err
+		}
+
+		log.Debug("Function returned a response", "response", redacted{rsp})
+		return rsp, err
+	}
+}
+
+// redacted lazily formats a RunFunctionRequest or RunFunctionResponse with its
+// secrets redacted. The logger only calls String if the log entry is written,
+// so we only pay the cost of redaction when debug logging is enabled.
+type redacted struct {
+	msg any
+}
+
+// String returns the JSON representation of the redacted message.
+func (r redacted) String() string {
+	var msg proto.Message
+	switch m := r.msg.(type) {
+	case *v1.RunFunctionRequest:
+		msg = redact.Request(m, redact.WithSecretData())
+	case *v1.RunFunctionResponse:
+		msg = redact.Response(m, redact.WithSecretData())
+	case *v1beta1.RunFunctionRequest:
+		req := &v1.RunFunctionRequest{}
+		if !convert(m, req) {
+			return "<unknown>"
+		}
+		msg = redact.Request(req, redact.WithSecretData())
+	case *v1beta1.RunFunctionResponse:
+		rsp := &v1.RunFunctionResponse{}
+		if !convert(m, rsp) {
+			return "<unknown>"
+		}
+		msg = redact.Response(rsp, redact.WithSecretData())
+	default:
+		return "<unknown>"
+	}
+
+	b, err := protojson.Marshal(msg)
+	if err != nil {
+		return "<unknown>"
+	}
+	return string(b)
+}
+
+// recoveryInterceptor returns an interceptor that converts panics into gRPC
+// Internal errors.
+func recoveryInterceptor(log logging.Logger) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (rsp any, err error) {
+		defer func() {
+			if p := recover(); p != nil {
+				log.Info("Recovered from panic while handling request", "method", info.FullMethod, "panic", p, "stack", string(debug.Stack()))
+				rsp, err = nil, status.Errorf(codes.Internal, "recovered from panic: %v", p)
+			}
+		}()
+		return handler(ctx, req)
+	}
+}
+
+// timeoutInterceptor returns an interceptor that sets a deadline on the context
+// passed to RunFunction.
+func timeoutInterceptor(timeout time.Duration) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		if !isRunFunction(info.FullMethod) {
+			return handler(ctx, req)
+		}
+		ctx, cancel := context.WithTimeout(ctx, timeout)
+		defer cancel()
+		return handler(ctx, req)
+	}
+}
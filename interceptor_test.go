@@ -0,0 +1,308 @@
+/*
+Copyright 2026 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package function
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	healthgrpc "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+
+	xplogging "github.com/crossplane/crossplane-runtime/v2/pkg/logging"
+
+	"github.com/crossplane/function-sdk-go/logging"
+	v1 "github.com/crossplane/function-sdk-go/proto/v1"
+	"github.com/crossplane/function-sdk-go/proto/v1beta1"
+	"github.com/crossplane/function-sdk-go/redact"
+	"github.com/crossplane/function-sdk-go/resource"
+)
+
+func TestRecoveryInterceptor(t *testing.T) {
+	type want struct {
+		rsp   any
+		code  codes.Code
+		stack bool
+	}
+
+	cases := map[string]struct {
+		reason  string
+		handler grpc.UnaryHandler
+		want    want
+	}{
+		"Panic": {
+			reason: "A panic should be converted to an Internal error.",
+			handler: func(_ context.Context, _ any) (any, error) {
+				var rsp *v1.RunFunctionResponse
+				return rsp.Meta.Tag, nil //nolint:protogetter // We want to panic.
+			},
+			want: want{
+				code:  codes.Internal,
+				stack: true,
+			},
+		},
+		"NoPanic": {
+			reason: "The handler's response should be returned if it doesn't panic.",
+			handler: func(_ context.Context, _ any) (any, error) {
+				return "hi", nil
+			},
+			want: want{
+				rsp:  "hi",
+				code: codes.OK,
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			log := &StackLogger{}
+			i := recoveryInterceptor(log)
+			info := &grpc.UnaryServerInfo{FullMethod: v1.FunctionRunnerService_RunFunction_FullMethodName}
+			rsp, err := i(context.Background(), nil, info, tc.handler)
+
+			// The logged stack should include the handler that panicked.
+			stack := strings.Contains(log.stack, "TestRecoveryInterceptor")
+
+			if diff := cmp.Diff(tc.want.rsp, rsp); diff != "" {
+				t.Errorf("\n%s\ni(...): -want rsp, +got rsp:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.code, status.Code(err)); diff != "" {
+				t.Errorf("\n%s\ni(...): -want code, +got code:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.stack, stack); diff != "" {
+				t.Errorf("\n%s\ni(...): -want stack logged, +got stack logged:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+// StackLogger records the stack trace logged at info level.
+type StackLogger struct {
+	RecordingLogger
+
+	stack string
+}
+
+func (l *StackLogger) Info(_ string, kv ...any) {
+	for i := 0; i+1 < len(kv); i += 2 {
+		if kv[i] == "stack" {
+			l.stack, _ = kv[i+1].(string)
+		}
+	}
+}
+
+func TestTimeoutInterceptor(t *testing.T) {
+	type want struct {
+		deadline bool
+	}
+
+	cases := map[string]struct {
+		reason string
+		method string
+		want   want
+	}{
+		"RunFunction": {
+			reason: "RunFunction should be called with a deadline.",
+			method: v1.FunctionRunnerService_RunFunction_FullMethodName,
+			want: want{
+				deadline: true,
+			},
+		},
+		"HealthCheck": {
+			reason: "Other methods should not be called with a deadline.",
+			method: healthgrpc.Health_Check_FullMethodName,
+			want: want{
+				deadline: false,
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			i := timeoutInterceptor(time.Minute)
+			info := &grpc.UnaryServerInfo{FullMethod: tc.method}
+
+			var deadline bool
+			_, _ = i(context.Background(), nil, info, func(ctx context.Context, _ any) (any, error) {
+				_, deadline = ctx.Deadline()
+				return nil, nil
+			})
+
+			if diff := cmp.Diff(tc.want.deadline, deadline); diff != "" {
+				t.Errorf("\n%s\ni(...): -want deadline, +got deadline:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestLoggingInterceptor(t *testing.T) {
+	type want struct {
+		kv map[string]any
+	}
+
+	cases := map[string]struct {
+		reason string
+		req    *v1.RunFunctionRequest
+		want   want
+	}{
+		"NamespacedXR": {
+			reason: "The Logger should include the tag, request ID, and XR details.",
+			req: &v1.RunFunctionRequest{
+				Meta: &v1.RequestMeta{Tag: "hi"},
+				Observed: &v1.State{
+					Composite: &v1.Resource{
+						Resource: resource.MustStructJSON(`{
+							"apiVersion": "example.org/v1",
+							"kind": "XR",
+							"metadata": {"name": "cool-xr", "namespace": "default"}
+						}`),
+					},
+				},
+			},
+			want: want{
+				kv: map[string]any{
+					logging.KeyTag:          "hi",
+					logging.KeyRequestID:    "some-uuid",
+					logging.KeyXRAPIVersion: "example.org/v1",
+					logging.KeyXRKind:       "XR",
+					logging.KeyXRName:       "cool-xr",
+					logging.KeyXRNamespace:  "default",
+				},
+			},
+		},
+		"ClusterScopedXR": {
+			reason: "The Logger should omit the namespace of a cluster scoped XR.",
+			req: &v1.RunFunctionRequest{
+				Meta: &v1.RequestMeta{Tag: "hi"},
+				Observed: &v1.State{
+					Composite: &v1.Resource{
+						Resource: resource.MustStructJSON(`{
+							"apiVersion": "example.org/v1",
+							"kind": "XR",
+							"metadata": {"name": "cool-xr"}
+						}`),
+					},
+				},
+			},
+			want: want{
+				kv: map[string]any{
+					logging.KeyTag:          "hi",
+					logging.KeyRequestID:    "some-uuid",
+					logging.KeyXRAPIVersion: "example.org/v1",
+					logging.KeyXRKind:       "XR",
+					logging.KeyXRName:       "cool-xr",
+				},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			i := loggingInterceptor(&RecordingLogger{})
+			info := &grpc.UnaryServerInfo{FullMethod: v1.FunctionRunnerService_RunFunction_FullMethodName}
+
+			var got *RecordingLogger
+			_, _ = i(context.Background(), tc.req, info, func(ctx context.Context, _ any) (any, error) {
+				got, _ = logging.FromContext(ctx).(*RecordingLogger)
+				return nil, nil
+			})
+			if got == nil {
+				t.Fatalf("\n%s\ni(...): context passed to handler does not carry a *RecordingLogger", tc.reason)
+			}
+
+			kv := map[string]any{}
+			for j := 0; j+1 < len(got.kv); j += 2 {
+				kv[got.kv[j].(string)] = got.kv[j+1]
+			}
+			// Request IDs are random.
+			if id, ok := kv[logging.KeyRequestID].(string); ok && id != "" {
+				kv[logging.KeyRequestID] = "some-uuid"
+			}
+
+			if diff := cmp.Diff(tc.want.kv, kv); diff != "" {
+				t.Errorf("\n%s\ni(...): -want key-values, +got key-values:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+// RecordingLogger records the key-value pairs it was created with.
+type RecordingLogger struct {
+	kv []any
+}
+
+func (l *RecordingLogger) Info(_ string, _ ...any)  {}
+func (l *RecordingLogger) Debug(_ string, _ ...any) {}
+
+func (l *RecordingLogger) WithValues(kv ...any) xplogging.Logger {
+	return &RecordingLogger{kv: append(append([]any{}, l.kv...), kv...)}
+}
+
+func TestRedacted(t *testing.T) {
+	cases := map[string]struct {
+		reason string
+		msg    any
+		want   string
+	}{
+		"V1Request": {
+			reason: "We should redact credentials from a v1 request.",
+			msg: &v1.RunFunctionRequest{
+				Credentials: map[string]*v1.Credentials{
+					"cloud": {Source: &v1.Credentials_CredentialData{CredentialData: &v1.CredentialData{
+						Data: map[string][]byte{"key": []byte("secret")},
+					}}},
+				},
+			},
+			want: `{"credentials":{"cloud":{"credentialData":{"data":{"key":"` + base64.StdEncoding.EncodeToString([]byte(redact.Digest([]byte("secret")))) + `"}}}}}`,
+		},
+		"V1Beta1Response": {
+			reason: "We should redact connection details from a v1beta1 response.",
+			msg: &v1beta1.RunFunctionResponse{
+				Desired: &v1beta1.State{
+					Composite: &v1beta1.Resource{ConnectionDetails: map[string][]byte{"password": []byte("hunter2")}},
+				},
+			},
+			want: `{"desired":{"composite":{"connectionDetails":{"password":"` + base64.StdEncoding.EncodeToString([]byte(redact.Digest([]byte("hunter2")))) + `"}}}}`,
+		},
+		"Unknown": {
+			reason: "We should not format messages that aren't RunFunction requests or responses.",
+			msg:    "hello",
+			want:   "<unknown>",
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			// protojson output isn't stable, so we compact it before comparing.
+			got := &bytes.Buffer{}
+			if err := json.Compact(got, []byte(redacted{tc.msg}.String())); err != nil {
+				got.WriteString(redacted{tc.msg}.String())
+			}
+			if diff := cmp.Diff(tc.want, got.String()); diff != "" {
+				t.Errorf("\n%s\nString(): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,37 @@
+/*
+Copyright 2026 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package object contains utilities for working with typed Kubernetes objects.
+package object
+
+import (
+	"reflect"
+
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/crossplane/function-sdk-go/errors"
+)
+
+// New returns a new, empty object of type T, which must be a pointer to a
+// struct.
+func New[T runtime.Object]() (T, error) {
+	var zero T
+	t := reflect.TypeOf(zero)
+	if t == nil || t.Kind() != reflect.Pointer || t.Elem().Kind() != reflect.Struct {
+		return zero, errors.Errorf("cannot create object of type %v: type must be a pointer to a struct", t)
+	}
+	return reflect.New(t.Elem()).Interface().(T), nil //nolint:forcetypeassert // reflect.New(t.Elem()) always returns a T.
+}
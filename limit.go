@@ -0,0 +1,140 @@
+/*
+Copyright 2026 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package function
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// WithConcurrencyLimit limits how many RunFunctionRequests the Function
+// handles concurrently. Health checks and other gRPC calls aren't limited.
+//
+// If queueTimeout is zero, a request that arrives while limit requests are in
+// flight is rejected immediately with gRPC status code ResourceExhausted.
+// Otherwise the request waits up to queueTimeout for another request to
+// complete before it's rejected. Crossplane retries rejected requests.
+func WithConcurrencyLimit(limit int, queueTimeout time.Duration) ServeOption {
+	return func(o *ServeOptions) error {
+		if limit < 1 {
+			return errors.New("concurrency limit must be at least 1")
+		}
+		if queueTimeout < 0 {
+			return errors.New("concurrency limit queue timeout must not be negative")
+		}
+		o.concurrencyLimit = limit
+		o.queueTimeout = queueTimeout
+		return nil
+	}
+}
+
+// A concurrencyLimiter bounds the number of in-flight RunFunctionRequests.
+type concurrencyLimiter struct {
+	slots        chan struct{}
+	queueTimeout time.Duration
+
+	inFlight prometheus.Gauge
+	rejected prometheus.Counter
+}
+
+func newConcurrencyLimiter(limit int, queueTimeout time.Duration) *concurrencyLimiter {
+	return &concurrencyLimiter{
+		slots:        make(chan struct{}, limit),
+		queueTimeout: queueTimeout,
+		inFlight: prometheus.NewGauge(prometheus.GaugeOpts{
+			Name: "function_run_function_requests_in_flight",
+			Help: "Number of RunFunctionRequests currently being handled.",
+		}),
+		rejected: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: "function_run_function_requests_rejected_total",
+			Help: "Total number of RunFunctionRequests rejected because the concurrency limit was reached.",
+		}),
+	}
+}
+
+// Describe implements prometheus.Collector.
+func (l *concurrencyLimiter) Describe(ch chan<- *prometheus.Desc) {
+	l.inFlight.Describe(ch)
+	l.rejected.Describe(ch)
+}
+
+// Collect implements prometheus.Collector.
+func (l *concurrencyLimiter) Collect(ch chan<- prometheus.Metric) {
+	l.inFlight.Collect(ch)
+	l.rejected.Collect(ch)
+}
+
+// acquire a slot, waiting up to the queue timeout if none is available. It
+// returns a gRPC status error if no slot could be acquired.
+func (l *concurrencyLimiter) acquire(ctx context.Context) error {
+	select {
+	case l.slots <- struct{}{}:
+		return nil
+	default:
+	}
+
+	if l.queueTimeout == 0 {
+		return l.reject()
+	}
+
+	t := time.NewTimer(l.queueTimeout)
+	defer t.Stop()
+
+	select {
+	case l.slots <- struct{}{}:
+		return nil
+	case <-t.C:
+		return l.reject()
+	case <-ctx.Done():
+		// The caller gave up while the request was queued. That's not a
+		// rejection, so report why the context ended instead.
+		return status.FromContextError(ctx.Err()).Err()
+	}
+}
+
+func (l *concurrencyLimiter) reject() error {
+	l.rejected.Inc()
+	return status.Errorf(codes.ResourceExhausted, "too many concurrent requests - limit is %d", cap(l.slots))
+}
+
+func (l *concurrencyLimiter) release() {
+	<-l.slots
+}
+
+// Interceptor returns an interceptor that limits concurrent RunFunction calls.
+func (l *concurrencyLimiter) Interceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		if !isRunFunction(info.FullMethod) {
+			return handler(ctx, req)
+		}
+		if err := l.acquire(ctx); err != nil {
+			return nil, err
+		}
+		defer l.release()
+
+		l.inFlight.Inc()
+		defer l.inFlight.Dec()
+
+		return handler(ctx, req)
+	}
+}
@@ -0,0 +1,205 @@
+/*
+Copyright 2026 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package function
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	healthgrpc "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+
+	v1 "github.com/crossplane/function-sdk-go/proto/v1"
+)
+
+func TestConcurrencyLimiterInterceptor(t *testing.T) {
+	type want struct {
+		code     codes.Code
+		rejected float64
+	}
+
+	cases := map[string]struct {
+		reason       string
+		queueTimeout time.Duration
+		// How long the in-flight request holds its slot.
+		hold time.Duration
+		// Whether the queued request's context is cancelled.
+		cancelled bool
+		method    string
+		want      want
+	}{
+		"RejectImmediately": {
+			reason: "A request should be rejected immediately when the limit is reached and there is no queue timeout.",
+			hold:   time.Second,
+			method: v1.FunctionRunnerService_RunFunction_FullMethodName,
+			want: want{
+				code:     codes.ResourceExhausted,
+				rejected: 1,
+			},
+		},
+		"QueueTimeout": {
+			reason:       "A request should be rejected if no slot frees up within the queue timeout.",
+			queueTimeout: 10 * time.Millisecond,
+			hold:         time.Second,
+			method:       v1.FunctionRunnerService_RunFunction_FullMethodName,
+			want: want{
+				code:     codes.ResourceExhausted,
+				rejected: 1,
+			},
+		},
+		"CancelledWhileQueued": {
+			reason:       "A queued request whose context is cancelled should return the context's status, not ResourceExhausted.",
+			queueTimeout: 10 * time.Second,
+			hold:         10 * time.Millisecond,
+			cancelled:    true,
+			method:       v1.FunctionRunnerService_RunFunction_FullMethodName,
+			want: want{
+				code: codes.Canceled,
+			},
+		},
+		"QueueUntilSlotFree": {
+			reason:       "A queued request should be handled once a slot frees up.",
+			queueTimeout: 10 * time.Second,
+			hold:         10 * time.Millisecond,
+			method:       v1.FunctionRunnerService_RunFunction_FullMethodName,
+			want: want{
+				code: codes.OK,
+			},
+		},
+		"NotRunFunction": {
+			reason: "Calls other than RunFunction should not be limited.",
+			hold:   time.Second,
+			method: healthgrpc.Health_Check_FullMethodName,
+			want: want{
+				code: codes.OK,
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			l := newConcurrencyLimiter(1, tc.queueTimeout)
+			i := l.Interceptor()
+			info := &grpc.UnaryServerInfo{FullMethod: v1.FunctionRunnerService_RunFunction_FullMethodName}
+
+			// Occupy the only slot.
+			started := make(chan struct{})
+			done := make(chan struct{})
+			go func() {
+				_, _ = i(context.Background(), nil, info, func(_ context.Context, _ any) (any, error) {
+					close(started)
+					time.Sleep(tc.hold)
+					return nil, nil
+				})
+				close(done)
+			}()
+			<-started
+
+			ctx, cancel := context.WithCancel(context.Background())
+			if tc.cancelled {
+				cancel()
+			}
+			defer cancel()
+
+			_, err := i(ctx, nil, &grpc.UnaryServerInfo{FullMethod: tc.method}, func(_ context.Context, _ any) (any, error) {
+				return nil, nil
+			})
+			<-done
+
+			if diff := cmp.Diff(tc.want.code, status.Code(err)); diff != "" {
+				t.Errorf("\n%s\ni(...): -want code, +got code:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.rejected, metricValue(t, l.rejected)); diff != "" {
+				t.Errorf("\n%s\ni(...): -want rejected, +got rejected:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(float64(0), metricValue(t, l.inFlight)); diff != "" {
+				t.Errorf("\n%s\ni(...): -want in-flight, +got in-flight:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+// TestServeContext_ConcurrencyLimitWithRequestTimeout verifies that the
+// request timeout bounds how long a request waits for a concurrency slot.
+func TestServeContext_ConcurrencyLimitWithRequestTimeout(t *testing.T) {
+	started := make(chan struct{})
+	fn := &BlockingFunctionServer{
+		started: started,
+		wait:    2 * time.Second,
+		rsp:     &v1.RunFunctionResponse{},
+	}
+
+	grpcPort := getAvailablePort(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		_ = ServeContext(ctx, fn,
+			Listen("tcp", fmt.Sprintf(":%d", grpcPort)),
+			Insecure(true),
+			WithMetricsServer(""),
+			WithConcurrencyLimit(1, 10*time.Second),
+			WithRequestTimeout(100*time.Millisecond),
+		)
+	}()
+
+	conn, err := grpc.NewClient(fmt.Sprintf("localhost:%d", grpcPort), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	client := v1.NewFunctionRunnerServiceClient(conn)
+
+	// Occupy the only slot.
+	go func() {
+		_, _ = client.RunFunction(context.Background(), &v1.RunFunctionRequest{}, grpc.WaitForReady(true))
+	}()
+	<-started
+
+	begin := time.Now()
+	_, err = client.RunFunction(context.Background(), &v1.RunFunctionRequest{}, grpc.WaitForReady(true))
+	elapsed := time.Since(begin)
+
+	if diff := cmp.Diff(codes.DeadlineExceeded, status.Code(err)); diff != "" {
+		t.Errorf("RunFunction(...): -want code, +got code:\n%s", diff)
+	}
+	if elapsed >= fn.wait {
+		t.Errorf("RunFunction(...): queued request took %s, want less than the in-flight request's %s", elapsed, fn.wait)
+	}
+}
+
+// metricValue returns the value of the supplied counter or gauge.
+func metricValue(t *testing.T, m prometheus.Metric) float64 {
+	t.Helper()
+
+	out := &dto.Metric{}
+	if err := m.Write(out); err != nil {
+		t.Fatalf("cannot write metric: %v", err)
+	}
+	if c := out.GetCounter(); c != nil {
+		return c.GetValue()
+	}
+	return out.GetGauge().GetValue()
+}
@@ -0,0 +1,241 @@
+/*
+Copyright 2026 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package function
+
+import (
+	"context"
+	"io/fs"
+	"net"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// NetworkUnix is the network used to listen on a Unix domain socket.
+const NetworkUnix = "unix"
+
+// Environment variables set by systemd (or a compatible supervisor) when it
+// passes pre-opened sockets to a process. See sd_listen_fds(3).
+const (
+	envListenPID = "LISTEN_PID"
+	envListenFDs = "LISTEN_FDS"
+
+	// listenFDsStart is the first file descriptor passed by systemd.
+	listenFDsStart = 3
+)
+
+// staleSocketDialTimeout bounds how long we wait to determine whether a
+// process is still serving an existing Unix socket.
+const staleSocketDialTimeout = time.Second
+
+// A UnixSocketOwner is the user and group that should own a Unix domain socket.
+type UnixSocketOwner struct {
+	UID int
+	GID int
+}
+
+// ListenUnix configures the Function to listen for RunFunctionRequests on a
+// Unix domain socket at the supplied path, for example on a volume shared with
+// a sidecar. Any stale socket left at the path by a previous process is
+// removed, but the Function fails to serve if another process is still
+// listening on it. The socket's permissions are set to the supplied mode. If mode is
+// zero the permissions are determined by the process's umask.
+//
+// Crossplane sends requests using mTLS over TCP, so a Function listening on a
+// Unix socket is typically served with Insecure and called by a local proxy.
+func ListenUnix(path string, mode fs.FileMode) ServeOption {
+	return func(o *ServeOptions) error {
+		o.Network = NetworkUnix
+		o.Address = path
+		o.UnixSocketMode = mode
+		return nil
+	}
+}
+
+// WithUnixSocketOwner configures the user and group that should own the Unix
+// domain socket created by ListenUnix.
+func WithUnixSocketOwner(uid, gid int) ServeOption {
+	return func(o *ServeOptions) error {
+		o.UnixSocketOwner = &UnixSocketOwner{UID: uid, GID: gid}
+		return nil
+	}
+}
+
+// WithListener configures the Function to serve RunFunctionRequests using the
+// supplied listener, rather than creating its own. The listener is closed when
+// the Function stops serving.
+func WithListener(lis net.Listener) ServeOption {
+	return func(o *ServeOptions) error {
+		o.Listener = lis
+		return nil
+	}
+}
+
+// SocketActivation configures the Function to serve RunFunctionRequests using
+// a listening socket inherited from systemd or a compatible supervisor, per
+// sd_listen_fds(3). The Function fails to serve if the process wasn't passed
+// exactly one socket. The LISTEN_PID and LISTEN_FDS environment variables are
+// unset once they're read, so they aren't inherited by child processes.
+func SocketActivation() ServeOption {
+	return func(o *ServeOptions) error {
+		o.SocketActivation = true
+		return nil
+	}
+}
+
+// listen for RunFunctionRequests as configured by the supplied options.
+func listen(ctx context.Context, so *ServeOptions) (net.Listener, error) {
+	switch {
+	case so.Listener != nil:
+		return so.Listener, nil
+	case so.SocketActivation:
+		return listenInherited()
+	case so.Network == NetworkUnix:
+		return listenUnix(ctx, so)
+	}
+
+	listenConfig := &net.ListenConfig{}
+	lis, err := listenConfig.Listen(ctx, so.Network, so.Address)
+	return lis, errors.Wrapf(err, "cannot listen for %s connections at address %q", so.Network, so.Address)
+}
+
+// listenInherited returns a listener for the socket passed to this process by
+// systemd.
+func listenInherited() (net.Listener, error) {
+	pidEnv, fdsEnv := os.Getenv(envListenPID), os.Getenv(envListenFDs)
+	_ = os.Unsetenv(envListenPID)
+	_ = os.Unsetenv(envListenFDs)
+
+	pid, err := strconv.Atoi(pidEnv)
+	if err != nil || pid != os.Getpid() {
+		return nil, errors.Errorf("no sockets were passed to this process - %s is not %d", envListenPID, os.Getpid())
+	}
+	n, err := strconv.Atoi(fdsEnv)
+	if err != nil {
+		return nil, errors.Wrapf(err, "cannot parse %s", envListenFDs)
+	}
+	if n != 1 {
+		return nil, errors.Errorf("%s is %d - exactly one socket must be passed to this process", envListenFDs, n)
+	}
+
+	f := os.NewFile(listenFDsStart, "LISTEN_FD_"+strconv.Itoa(listenFDsStart))
+	defer f.Close() //nolint:errcheck // net.FileListener dups the file descriptor.
+
+	lis, err := net.FileListener(f)
+	return lis, errors.Wrap(err, "cannot create listener from inherited socket")
+}
+
+// listenUnix listens on a Unix socket. If the socket's permissions or owner
+// are configured the socket is created in a private directory, then moved into
+// place once they're set. This ensures nothing can connect to the socket before
+// its permissions are set.
+func listenUnix(ctx context.Context, so *ServeOptions) (net.Listener, error) {
+	if err := removeStaleSocket(so.Address); err != nil {
+		return nil, err
+	}
+
+	listenConfig := &net.ListenConfig{}
+	if so.UnixSocketMode == 0 && so.UnixSocketOwner == nil {
+		lis, err := listenConfig.Listen(ctx, NetworkUnix, so.Address)
+		return lis, errors.Wrapf(err, "cannot listen for %s connections at address %q", NetworkUnix, so.Address)
+	}
+
+	// MkdirTemp creates a directory only we can access.
+	dir, err := os.MkdirTemp(filepath.Dir(so.Address), ".sock")
+	if err != nil {
+		return nil, errors.Wrapf(err, "cannot create directory for Unix socket %q", so.Address)
+	}
+	defer os.RemoveAll(dir) //nolint:errcheck // The directory is empty once the socket is moved.
+
+	tmp := filepath.Join(dir, "s")
+	lis, err := listenConfig.Listen(ctx, NetworkUnix, tmp)
+	if err != nil {
+		return nil, errors.Wrapf(err, "cannot listen for %s connections at address %q", NetworkUnix, so.Address)
+	}
+	ul := lis.(*net.UnixListener) //nolint:forcetypeassert // Listening on a Unix network always returns a *net.UnixListener.
+
+	// The listener would otherwise remove tmp when it's closed, not the
+	// path we move the socket to.
+	ul.SetUnlinkOnClose(false)
+
+	if so.UnixSocketMode != 0 {
+		if err := os.Chmod(tmp, so.UnixSocketMode); err != nil {
+			_ = ul.Close()
+			return nil, errors.Wrapf(err, "cannot set permissions of Unix socket %q", so.Address)
+		}
+	}
+	if so.UnixSocketOwner != nil {
+		if err := os.Chown(tmp, so.UnixSocketOwner.UID, so.UnixSocketOwner.GID); err != nil {
+			_ = ul.Close()
+			return nil, errors.Wrapf(err, "cannot set owner of Unix socket %q", so.Address)
+		}
+	}
+	if err := os.Rename(tmp, so.Address); err != nil {
+		_ = ul.Close()
+		return nil, errors.Wrapf(err, "cannot move Unix socket into place at %q", so.Address)
+	}
+
+	return &unixListener{UnixListener: ul, path: so.Address}, nil
+}
+
+// A unixListener removes its socket when it's closed.
+type unixListener struct {
+	*net.UnixListener
+
+	path string
+	once sync.Once
+}
+
+// Close the listener and remove its socket.
+func (l *unixListener) Close() error {
+	err := l.UnixListener.Close()
+	l.once.Do(func() { _ = os.Remove(l.path) })
+	return err
+}
+
+// removeStaleSocket removes a Unix socket left behind at the supplied path. It
+// refuses to remove anything that isn't a socket, or a socket that a process
+// is still listening on.
+func removeStaleSocket(path string) error {
+	fi, err := os.Lstat(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return errors.Wrapf(err, "cannot stat Unix socket %q", path)
+	}
+	if fi.Mode()&fs.ModeSocket == 0 {
+		return errors.Errorf("cannot listen on Unix socket %q - a file that is not a socket already exists", path)
+	}
+
+	// Only a socket nothing is listening on refuses connections. Anything
+	// else may belong to a running process.
+	conn, err := net.DialTimeout(NetworkUnix, path, staleSocketDialTimeout)
+	if err == nil {
+		_ = conn.Close()
+		return errors.Errorf("cannot listen on Unix socket %q - address in use by another process", path)
+	}
+	if !errors.Is(err, syscall.ECONNREFUSED) {
+		return errors.Wrapf(err, "cannot listen on Unix socket %q - cannot determine whether the address is in use", path)
+	}
+	return errors.Wrapf(os.Remove(path), "cannot remove stale Unix socket %q", path)
+}
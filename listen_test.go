@@ -0,0 +1,236 @@
+/*
+Copyright 2026 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package function
+
+import (
+	"context"
+	"io/fs"
+	"net"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/pkg/errors"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/protobuf/testing/protocmp"
+
+	v1 "github.com/crossplane/function-sdk-go/proto/v1"
+)
+
+func TestListenUnix(t *testing.T) {
+	type want struct {
+		mode fs.FileMode
+		err  error
+	}
+
+	cases := map[string]struct {
+		reason string
+		// setup prepares the path before we listen on it.
+		setup func(t *testing.T, path string)
+		mode  fs.FileMode
+		want  want
+	}{
+		"NewSocket": {
+			reason: "We should create a socket with the requested permissions.",
+			mode:   0o660,
+			want: want{
+				mode: 0o660,
+			},
+		},
+		"StaleSocket": {
+			reason: "We should replace a stale socket left behind by a previous process.",
+			setup: func(t *testing.T, path string) {
+				t.Helper()
+				listenConfig := &net.ListenConfig{}
+				lis, err := listenConfig.Listen(context.Background(), NetworkUnix, path)
+				if err != nil {
+					t.Fatal(err)
+				}
+				// Leave the socket file behind, as a crashed process would.
+				lis.(*net.UnixListener).SetUnlinkOnClose(false)
+				_ = lis.Close()
+			},
+			mode: 0o600,
+			want: want{
+				mode: 0o600,
+			},
+		},
+		"LiveSocket": {
+			reason: "We should refuse to replace a socket another process is listening on.",
+			setup: func(t *testing.T, path string) {
+				t.Helper()
+				listenConfig := &net.ListenConfig{}
+				lis, err := listenConfig.Listen(context.Background(), NetworkUnix, path)
+				if err != nil {
+					t.Fatal(err)
+				}
+				t.Cleanup(func() { _ = lis.Close() })
+			},
+			mode: 0o600,
+			want: want{
+				err: cmpopts.AnyError,
+			},
+		},
+		"NotASocket": {
+			reason: "We should refuse to remove a file that isn't a socket.",
+			setup: func(t *testing.T, path string) {
+				t.Helper()
+				if err := os.WriteFile(path, []byte("important"), 0o600); err != nil {
+					t.Fatal(err)
+				}
+			},
+			want: want{
+				err: cmpopts.AnyError,
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "fn.sock")
+			if tc.setup != nil {
+				tc.setup(t, path)
+			}
+
+			so := &ServeOptions{}
+			if err := ListenUnix(path, tc.mode)(so); err != nil {
+				t.Fatal(err)
+			}
+
+			lis, err := listen(context.Background(), so)
+			if diff := cmp.Diff(tc.want.err, err, cmpopts.EquateErrors()); diff != "" {
+				t.Fatalf("\n%s\nlisten(...): -want err, +got err:\n%s", tc.reason, diff)
+			}
+			if err != nil {
+				return
+			}
+
+			fi, err := os.Stat(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(tc.want.mode, fi.Mode().Perm()); diff != "" {
+				t.Errorf("\n%s\nlisten(...): -want mode, +got mode:\n%s", tc.reason, diff)
+			}
+
+			// Only the socket should be left in its directory.
+			entries, err := os.ReadDir(filepath.Dir(path))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(1, len(entries)); diff != "" {
+				t.Errorf("\n%s\nlisten(...): -want directory entries, +got directory entries:\n%s", tc.reason, diff)
+			}
+
+			// The socket should be removed when the listener is closed.
+			_ = lis.Close()
+			if _, err := os.Stat(path); !errors.Is(err, fs.ErrNotExist) {
+				t.Errorf("\n%s\nClose(): socket %q should have been removed: %v", tc.reason, path, err)
+			}
+		})
+	}
+}
+
+func TestListenSocketActivation(t *testing.T) {
+	type want struct {
+		err error
+	}
+
+	cases := map[string]struct {
+		reason string
+		pid    string
+		fds    string
+		want   want
+	}{
+		"NotForThisProcess": {
+			reason: "We should return an error if the sockets were passed to a different process.",
+			pid:    "1",
+			fds:    "1",
+			want:   want{err: cmpopts.AnyError},
+		},
+		"TooManySockets": {
+			reason: "We should return an error if more than one socket was passed to this process.",
+			pid:    strconv.Itoa(os.Getpid()),
+			fds:    "2",
+			want:   want{err: cmpopts.AnyError},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			t.Setenv(envListenPID, tc.pid)
+			t.Setenv(envListenFDs, tc.fds)
+
+			so := &ServeOptions{}
+			if err := SocketActivation()(so); err != nil {
+				t.Fatalf("\n%s\nSocketActivation(): options should be applied without inspecting sockets: %v", tc.reason, err)
+			}
+
+			_, err := listen(context.Background(), so)
+			if diff := cmp.Diff(tc.want.err, err, cmpopts.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nlisten(...): -want err, +got err:\n%s", tc.reason, diff)
+			}
+
+			for _, env := range []string{envListenPID, envListenFDs} {
+				if v, ok := os.LookupEnv(env); ok {
+					t.Errorf("\n%s\nlisten(...): %s should be unset, got %q", tc.reason, env, v)
+				}
+			}
+		})
+	}
+}
+
+// TestServeContext_WithListener verifies that a Function can be served on a
+// caller-supplied listener, in this case a Unix domain socket.
+func TestServeContext_WithListener(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "fn.sock")
+	listenConfig := &net.ListenConfig{}
+	lis, err := listenConfig.Listen(context.Background(), NetworkUnix, path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := &v1.RunFunctionResponse{Meta: &v1.ResponseMeta{Tag: "unix"}}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		_ = ServeContext(ctx, &MockFunctionServer{rsp: want},
+			WithListener(lis),
+			Insecure(true),
+			WithMetricsServer(""),
+		)
+	}()
+
+	conn, err := grpc.NewClient("unix://"+path, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+
+	got, err := v1.NewFunctionRunnerServiceClient(conn).RunFunction(context.Background(), &v1.RunFunctionRequest{}, grpc.WaitForReady(true))
+	if err != nil {
+		t.Fatalf("RunFunction(...): %v", err)
+	}
+	if diff := cmp.Diff(want, got, protocmp.Transform()); diff != "" {
+		t.Errorf("RunFunction(...): -want rsp, +got rsp:\n%s", diff)
+	}
+}
@@ -21,9 +21,13 @@ limitations under the License.
 package logging
 
 import (
+	"context"
+	"slices"
+
 	"github.com/go-logr/logr"
 	"github.com/go-logr/zapr"
 	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
 
 	"github.com/crossplane/crossplane-runtime/v2/pkg/logging"
 
@@ -36,20 +40,132 @@ type Logger logging.Logger
 // NewNopLogger returns a Logger that does nothing.
 func NewNopLogger() Logger { return logging.NewNopLogger() }
 
+// An ErrorLogger is a Logger that can log errors at error level. Loggers
+// returned by NewLogger and NewLogrLogger are ErrorLoggers.
+type ErrorLogger interface {
+	Logger
+
+	// Error logs the supplied error and message with optional structured
+	// data, at error level.
+	Error(err error, msg string, keysAndValues ...any)
+}
+
+// Error logs the supplied error and message using the supplied Logger. It logs
+// at error level if the Logger is an ErrorLogger. Otherwise it logs at info
+// level, with the error under the "error" key.
+func Error(log Logger, err error, msg string, keysAndValues ...any) {
+	switch l := log.(type) {
+	case logrLogger:
+		// Report our caller, not this function, as the caller.
+		l.log.WithCallDepth(1).Error(err, msg, keysAndValues...) //nolint:loggercheck // False positive - loggercheck thinks there's an odd number of args.
+	case ErrorLogger:
+		l.Error(err, msg, keysAndValues...)
+	default:
+		log.Info(msg, append(slices.Clip(keysAndValues), "error", err)...) //nolint:loggercheck // False positive - loggercheck thinks there's an odd number of args.
+	}
+}
+
 // NewLogrLogger returns a Logger that is satisfied by the supplied logr.Logger,
 // which may be satisfied in turn by various logging implementations (Zap, klog,
 // etc). Debug messages are logged at V(1).
 func NewLogrLogger(l logr.Logger) Logger {
-	return logging.NewLogrLogger(l)
+	return logrLogger{log: l}
+}
+
+// A logrLogger is crossplane-runtime's logr Logger, extended to log errors.
+type logrLogger struct {
+	log logr.Logger
+}
+
+// Info logs a message at V(0).
+func (l logrLogger) Info(msg string, keysAndValues ...any) {
+	l.log.Info(msg, keysAndValues...) //nolint:loggercheck // False positive - loggercheck thinks there's an odd number of args.
+}
+
+// Debug logs a message at V(1).
+func (l logrLogger) Debug(msg string, keysAndValues ...any) {
+	l.log.V(1).Info(msg, keysAndValues...) //nolint:loggercheck // False positive - loggercheck thinks there's an odd number of args.
+}
+
+// Error logs an error at error level.
+func (l logrLogger) Error(err error, msg string, keysAndValues ...any) {
+	l.log.Error(err, msg, keysAndValues...) //nolint:loggercheck // False positive - loggercheck thinks there's an odd number of args.
+}
+
+// WithValues returns a Logger that includes the supplied structured data.
+func (l logrLogger) WithValues(keysAndValues ...any) logging.Logger {
+	return logrLogger{log: l.log.WithValues(keysAndValues...)} //nolint:loggercheck // False positive - loggercheck thinks there's an odd number of args.
 }
 
-// NewLogger returns a new logger.
-func NewLogger(debug bool) (logging.Logger, error) {
-	o := []zap.Option{zap.AddCallerSkip(1)}
+// NewLogger returns a new logger. When debug is true the logger uses zap's
+// development preset, which logs human-friendly console output at debug level.
+// Otherwise it uses zap's production preset, which logs sampled JSON output at
+// info level. Options override the preset.
+func NewLogger(debug bool, o ...Option) (logging.Logger, error) {
+	opts := &Options{}
+	for _, fn := range o {
+		if err := fn(opts); err != nil {
+			return nil, errors.Wrap(err, "cannot apply logger option")
+		}
+	}
+
+	cfg := zap.NewProductionConfig()
 	if debug {
-		zl, err := zap.NewDevelopment(o...)
-		return NewLogrLogger(zapr.NewLogger(zl)), errors.Wrap(err, "cannot create development zap logger")
+		cfg = zap.NewDevelopmentConfig()
+	}
+	if opts.Encoding != "" {
+		cfg.Encoding = string(opts.Encoding)
+	}
+	if opts.Level != nil {
+		// logr V-levels are negative zap levels. V(0) is zap's info level,
+		// V(1) is zap's debug level, and so on.
+		cfg.Level = zap.NewAtomicLevelAt(zapcore.Level(-*opts.Level))
+	}
+	if opts.Sampling != nil {
+		cfg.Sampling = &zap.SamplingConfig{Initial: opts.Sampling.Initial, Thereafter: opts.Sampling.Thereafter}
+		if opts.Sampling.Initial == 0 {
+			cfg.Sampling = nil
+		}
+	}
+
+	zl, err := cfg.Build(zap.AddCallerSkip(1))
+	if err != nil {
+		return nil, errors.Wrapf(err, "cannot create %s zap logger", cfg.Encoding)
+	}
+
+	l := zapr.NewLogger(zl)
+	if opts.Redactor != nil {
+		l = logr.New(newRedactingSink(l.GetSink(), opts.Redactor))
+	}
+	return NewLogrLogger(l), nil
+}
+
+// Well-known keys used by loggers the SDK adds to the RunFunction context.
+const (
+	KeyTag          = "tag"
+	KeyRequestID    = "request-id"
+	KeyXRAPIVersion = "xr-apiversion"
+	KeyXRKind       = "xr-kind"
+	KeyXRName       = "xr-name"
+	KeyXRNamespace  = "xr-namespace"
+)
+
+type contextKey struct{}
+
+// NewContext returns a copy of the supplied context that carries the supplied
+// Logger. Use FromContext to retrieve it.
+func NewContext(ctx context.Context, log Logger) context.Context {
+	return context.WithValue(ctx, contextKey{}, log)
+}
+
+// FromContext returns the Logger carried by the supplied context. When a
+// Function is served by this SDK the context passed to RunFunction carries a
+// Logger that includes the request's tag, request ID, and composite resource
+// (XR). FromContext returns a Logger that does nothing if the context doesn't
+// carry one.
+func FromContext(ctx context.Context) Logger {
+	if log, ok := ctx.Value(contextKey{}).(Logger); ok {
+		return log
 	}
-	zl, err := zap.NewProduction(o...)
-	return NewLogrLogger(zapr.NewLogger(zl)), errors.Wrap(err, "cannot create production zap logger")
+	return NewNopLogger()
 }
@@ -0,0 +1,216 @@
+/*
+Copyright 2026 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package logging
+
+import (
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/go-logr/logr/funcr"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+
+	"github.com/crossplane/function-sdk-go/errors"
+)
+
+func TestNewLogger(t *testing.T) {
+	type args struct {
+		debug bool
+		o     []Option
+	}
+	type want struct {
+		err error
+	}
+
+	cases := map[string]struct {
+		reason string
+		args   args
+		want   want
+	}{
+		"Production": {
+			reason: "We should create a production logger with no options.",
+			args:   args{},
+		},
+		"Development": {
+			reason: "We should create a development logger with no options.",
+			args:   args{debug: true},
+		},
+		"AllOptions": {
+			reason: "We should create a logger with all options set.",
+			args: args{
+				o: []Option{
+					WithEncoding(EncodingConsole),
+					WithLevel(2),
+					WithSampling(10, 100),
+					WithRedactor(RedactKeys("password")),
+				},
+			},
+		},
+		"DisableSampling": {
+			reason: "We should create a logger with sampling disabled.",
+			args: args{
+				o: []Option{WithSampling(0, 0)},
+			},
+		},
+		"UnsupportedEncoding": {
+			reason: "We should return an error if the encoding is unsupported.",
+			args: args{
+				o: []Option{WithEncoding("xml")},
+			},
+			want: want{
+				err: cmpopts.AnyError,
+			},
+		},
+		"NegativeLevel": {
+			reason: "We should return an error if the level is negative.",
+			args: args{
+				o: []Option{WithLevel(-1)},
+			},
+			want: want{
+				err: cmpopts.AnyError,
+			},
+		},
+		"LevelTooHigh": {
+			reason: "We should return an error if the level is more verbose than zap supports.",
+			args: args{
+				o: []Option{WithLevel(MaxLevel + 1)},
+			},
+			want: want{
+				err: cmpopts.AnyError,
+			},
+		},
+		"ZeroThereafterSampling": {
+			reason: "We should return an error if sampling would drop every entry after the initial entries.",
+			args: args{
+				o: []Option{WithSampling(10, 0)},
+			},
+			want: want{
+				err: cmpopts.AnyError,
+			},
+		},
+		"NegativeSampling": {
+			reason: "We should return an error if sampling values are negative.",
+			args: args{
+				o: []Option{WithSampling(-1, 1)},
+			},
+			want: want{
+				err: cmpopts.AnyError,
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			_, err := NewLogger(tc.args.debug, tc.args.o...)
+			if diff := cmp.Diff(tc.want.err, err, cmpopts.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nNewLogger(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestRedactingSink(t *testing.T) {
+	type args struct {
+		values []any
+		kv     []any
+	}
+
+	cases := map[string]struct {
+		reason string
+		args   args
+		want   []string
+	}{
+		"RedactMessageValues": {
+			reason: "We should redact matching key-value pairs passed with a message.",
+			args: args{
+				kv: []any{"password", "hunter2", "user", "admin"},
+			},
+			want: []string{`"level"=0 "msg"="hello" "password"="<redacted>" "user"="admin"`},
+		},
+		"RedactWithValues": {
+			reason: "We should redact matching key-value pairs passed to WithValues.",
+			args: args{
+				values: []any{"password", "hunter2"},
+				kv:     []any{"user", "admin"},
+			},
+			want: []string{`"level"=0 "msg"="hello" "password"="<redacted>" "user"="admin"`},
+		},
+		"OddKeyValues": {
+			reason: "We should pass through a dangling key without a value.",
+			args: args{
+				kv: []any{"user", "admin", "password"},
+			},
+			want: []string{`"level"=0 "msg"="hello" "user"="admin" "password"="<no-value>"`},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			var got []string
+			fl := funcr.New(func(prefix, args string) {
+				got = append(got, args)
+			}, funcr.Options{})
+
+			l := logr.New(newRedactingSink(fl.GetSink(), RedactKeys("password")))
+			l.WithValues(tc.args.values...).Info("hello", tc.args.kv...)
+
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nInfo(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestError(t *testing.T) {
+	var got []string
+	fl := funcr.New(func(_, args string) {
+		got = append(got, args)
+	}, funcr.Options{})
+
+	cases := map[string]struct {
+		reason string
+		log    Logger
+		want   []string
+	}{
+		"ErrorLogger": {
+			reason: "We should log at error level if the Logger is an ErrorLogger.",
+			log:    NewLogrLogger(fl),
+			want:   []string{`"msg"="cannot reload" "error"="boom" "dir"="/certs"`},
+		},
+		"NotErrorLogger": {
+			reason: "We should log at info level, with an error key, if the Logger isn't an ErrorLogger.",
+			log:    infoOnlyLogger{NewLogrLogger(fl)},
+			want:   []string{`"level"=0 "msg"="cannot reload" "dir"="/certs" "error"="boom"`},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got = nil
+			Error(tc.log, errors.New("boom"), "cannot reload", "dir", "/certs")
+
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nError(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+// An infoOnlyLogger hides the Error method of the Logger it wraps.
+type infoOnlyLogger struct {
+	Logger
+}
@@ -0,0 +1,210 @@
+/*
+Copyright 2026 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package logging
+
+import (
+	"math"
+
+	"github.com/go-logr/logr"
+
+	"github.com/crossplane/function-sdk-go/errors"
+)
+
+// An Encoding determines how log entries are written.
+type Encoding string
+
+// Supported encodings.
+const (
+	// EncodingJSON writes each log entry as a JSON object.
+	EncodingJSON Encoding = "json"
+
+	// EncodingConsole writes each log entry as human-friendly, tab-separated
+	// text.
+	EncodingConsole Encoding = "console"
+)
+
+// Sampling limits how many identical log entries are written each second.
+// The first Initial entries with the same level and message are written, then
+// every Thereafter-th entry.
+type Sampling struct {
+	Initial    int
+	Thereafter int
+}
+
+// A Redactor returns the value that should be logged for the supplied key and
+// value. It's called for every key-value pair passed to a logger.
+type Redactor func(key string, value any) any
+
+// Redacted replaces values removed by RedactKeys.
+const Redacted = "<redacted>"
+
+// RedactKeys returns a Redactor that replaces the values of the supplied keys
+// with Redacted.
+func RedactKeys(keys ...string) Redactor {
+	redact := make(map[string]bool, len(keys))
+	for _, k := range keys {
+		redact[k] = true
+	}
+	return func(key string, value any) any {
+		if redact[key] {
+			return Redacted
+		}
+		return value
+	}
+}
+
+// Options configure a logger created by NewLogger. Unset options are
+// determined by the preset NewLogger uses.
+type Options struct {
+	// Encoding of log entries.
+	Encoding Encoding
+
+	// Level is the most verbose logr V-level that is logged. Zero logs only
+	// info and error messages, one adds debug messages.
+	Level *int
+
+	// Sampling of repeated log entries. Nil uses the preset's sampling.
+	Sampling *Sampling
+
+	// Redactor is called for every key-value pair logged.
+	Redactor Redactor
+}
+
+// An Option configures a logger created by NewLogger.
+type Option func(o *Options) error
+
+// WithEncoding configures the logger to write entries using the supplied
+// encoding.
+func WithEncoding(e Encoding) Option {
+	return func(o *Options) error {
+		switch e {
+		case EncodingJSON, EncodingConsole:
+		default:
+			return errors.Errorf("unsupported log encoding %q", e)
+		}
+		o.Encoding = e
+		return nil
+	}
+}
+
+// MaxLevel is the most verbose logr V-level supported by WithLevel.
+const MaxLevel = math.MaxInt8
+
+// WithLevel configures the most verbose logr V-level the logger writes. Zero
+// logs only info and error messages, one adds the debug messages logged by
+// Logger.Debug, and higher levels up to MaxLevel add messages logged using
+// logr's V method.
+func WithLevel(v int) Option {
+	return func(o *Options) error {
+		if v < 0 || v > MaxLevel {
+			return errors.Errorf("log level must be between 0 and %d, got %d", MaxLevel, v)
+		}
+		o.Level = &v
+		return nil
+	}
+}
+
+// WithSampling configures the logger to write the first initial log entries
+// with the same level and message each second, then every thereafter-th entry.
+// Use it to avoid flooding logs from hot loops. An initial value of zero
+// disables sampling. Otherwise thereafter must be at least one.
+func WithSampling(initial, thereafter int) Option {
+	return func(o *Options) error {
+		if initial < 0 || thereafter < 0 {
+			return errors.New("log sampling values must not be negative")
+		}
+		if initial > 0 && thereafter == 0 {
+			return errors.New("log sampling thereafter value must be at least 1 - use an initial value of 0 to disable sampling")
+		}
+		o.Sampling = &Sampling{Initial: initial, Thereafter: thereafter}
+		return nil
+	}
+}
+
+// WithRedactor configures the logger to pass every key-value pair through the
+// supplied Redactor before it's written. Use it to ensure sensitive values,
+// like credentials and connection details, are never logged.
+func WithRedactor(r Redactor) Option {
+	return func(o *Options) error {
+		o.Redactor = r
+		return nil
+	}
+}
+
+// A redactingSink is a logr.LogSink that redacts key-value pairs before passing
+// them to the LogSink it wraps.
+type redactingSink struct {
+	logr.LogSink
+
+	redact Redactor
+}
+
+// newRedactingSink wraps an initialized LogSink. Wrapping adds a frame between
+// the caller and the wrapped LogSink, so we increase its call depth by one.
+func newRedactingSink(s logr.LogSink, r Redactor) logr.LogSink {
+	if cd, ok := s.(logr.CallDepthLogSink); ok {
+		s = cd.WithCallDepth(1)
+	}
+	return &redactingSink{LogSink: s, redact: r}
+}
+
+// Init does nothing. The wrapped LogSink is already initialized.
+func (s *redactingSink) Init(_ logr.RuntimeInfo) {}
+
+// Info redacts and logs a non-error message.
+func (s *redactingSink) Info(level int, msg string, kv ...any) {
+	s.LogSink.Info(level, msg, s.redactAll(kv)...)
+}
+
+// Error redacts and logs an error message.
+func (s *redactingSink) Error(err error, msg string, kv ...any) {
+	s.LogSink.Error(err, msg, s.redactAll(kv)...)
+}
+
+// WithValues returns a LogSink that redacts and includes the supplied
+// key-value pairs with every log entry.
+func (s *redactingSink) WithValues(kv ...any) logr.LogSink {
+	return &redactingSink{LogSink: s.LogSink.WithValues(s.redactAll(kv)...), redact: s.redact}
+}
+
+// WithName returns a LogSink with the supplied name appended.
+func (s *redactingSink) WithName(name string) logr.LogSink {
+	return &redactingSink{LogSink: s.LogSink.WithName(name), redact: s.redact}
+}
+
+// WithCallDepth returns a LogSink that skips additional stack frames when
+// determining the caller.
+func (s *redactingSink) WithCallDepth(depth int) logr.LogSink {
+	cd, ok := s.LogSink.(logr.CallDepthLogSink)
+	if !ok {
+		return s
+	}
+	return &redactingSink{LogSink: cd.WithCallDepth(depth), redact: s.redact}
+}
+
+func (s *redactingSink) redactAll(kv []any) []any {
+	out := make([]any, len(kv))
+	copy(out, kv)
+	for i := 0; i+1 < len(out); i += 2 {
+		k, ok := out[i].(string)
+		if !ok {
+			continue
+		}
+		out[i+1] = s.redact(k, out[i+1])
+	}
+	return out
+}
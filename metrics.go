@@ -0,0 +1,141 @@
+/*
+Copyright 2026 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package function
+
+import (
+	"context"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/proto"
+
+	v1 "github.com/crossplane/function-sdk-go/proto/v1"
+	"github.com/crossplane/function-sdk-go/proto/v1beta1"
+)
+
+// Metric label names.
+const (
+	labelKind     = "kind"
+	labelSeverity = "severity"
+	labelReason   = "reason"
+	labelType     = "type"
+	labelStatus   = "status"
+)
+
+// responseMetrics are metrics derived from the content of each
+// RunFunctionResponse.
+type responseMetrics struct {
+	results    *prometheus.CounterVec
+	conditions *prometheus.CounterVec
+	desired    *prometheus.HistogramVec
+	size       *prometheus.HistogramVec
+}
+
+func newResponseMetrics() *responseMetrics {
+	return &responseMetrics{
+		results: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "function_run_function_response_results_total",
+			Help: "Total number of results returned by the Function, by composite resource kind, severity, and reason.",
+		}, []string{labelKind, labelSeverity, labelReason}),
+		conditions: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "function_run_function_response_conditions_total",
+			Help: "Total number of status conditions returned by the Function, by composite resource kind, type, and status.",
+		}, []string{labelKind, labelType, labelStatus}),
+		desired: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "function_run_function_response_desired_composed_resources",
+			Help:    "Number of desired composed resources returned by the Function, by composite resource kind.",
+			Buckets: prometheus.ExponentialBuckets(1, 2, 10),
+		}, []string{labelKind}),
+		size: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "function_run_function_response_size_bytes",
+			Help:    "Size in bytes of the serialized RunFunctionResponses returned by the Function, by composite resource kind.",
+			Buckets: prometheus.ExponentialBuckets(1024, 4, 8),
+		}, []string{labelKind}),
+	}
+}
+
+// Describe implements prometheus.Collector.
+func (m *responseMetrics) Describe(ch chan<- *prometheus.Desc) {
+	m.results.Describe(ch)
+	m.conditions.Describe(ch)
+	m.desired.Describe(ch)
+	m.size.Describe(ch)
+}
+
+// Collect implements prometheus.Collector.
+func (m *responseMetrics) Collect(ch chan<- prometheus.Metric) {
+	m.results.Collect(ch)
+	m.conditions.Collect(ch)
+	m.desired.Collect(ch)
+	m.size.Collect(ch)
+}
+
+// Observe the supplied response to the supplied request.
+func (m *responseMetrics) Observe(req *v1.RunFunctionRequest, rsp *v1.RunFunctionResponse) {
+	kind := req.GetObserved().GetComposite().GetResource().GetFields()["kind"].GetStringValue()
+
+	for _, r := range rsp.GetResults() {
+		m.results.WithLabelValues(kind, r.GetSeverity().String(), r.GetReason()).Inc()
+	}
+	for _, c := range rsp.GetConditions() {
+		m.conditions.WithLabelValues(kind, c.GetType(), c.GetStatus().String()).Inc()
+	}
+	m.desired.WithLabelValues(kind).Observe(float64(len(rsp.GetDesired().GetResources())))
+	m.size.WithLabelValues(kind).Observe(float64(proto.Size(rsp)))
+}
+
+// Interceptor returns an interceptor that observes every successful
+// RunFunction call.
+func (m *responseMetrics) Interceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		rsp, err := handler(ctx, req)
+		if err != nil || !isRunFunction(info.FullMethod) {
+			return rsp, err
+		}
+
+		switch r := rsp.(type) {
+		case *v1.RunFunctionResponse:
+			if rq, ok := req.(*v1.RunFunctionRequest); ok {
+				m.Observe(rq, r)
+			}
+		case *v1beta1.RunFunctionResponse:
+			// Crossplane v1.16 and earlier send v1beta1 requests. They're
+			// identical to v1, so we round-trip them rather than
+			// duplicating Observe.
+			rq, ok := req.(*v1beta1.RunFunctionRequest)
+			if !ok {
+				return rsp, err
+			}
+			gareq, garsp := &v1.RunFunctionRequest{}, &v1.RunFunctionResponse{}
+			if convert(rq, gareq) && convert(r, garsp) {
+				m.Observe(gareq, garsp)
+			}
+		}
+
+		return rsp, err
+	}
+}
+
+// convert from one protobuf message to another, identical one. It returns
+// false if the message can't be converted.
+func convert(from, to proto.Message) bool {
+	b, err := proto.Marshal(from)
+	if err != nil {
+		return false
+	}
+	return proto.Unmarshal(b, to) == nil
+}
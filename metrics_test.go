@@ -0,0 +1,124 @@
+/*
+Copyright 2026 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package function
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"google.golang.org/grpc"
+	"k8s.io/utils/ptr"
+
+	v1 "github.com/crossplane/function-sdk-go/proto/v1"
+	"github.com/crossplane/function-sdk-go/proto/v1beta1"
+	"github.com/crossplane/function-sdk-go/resource"
+)
+
+func TestResponseMetricsInterceptor(t *testing.T) {
+	type want struct {
+		fatal      float64
+		ready      float64
+		desiredSum float64
+	}
+
+	cases := map[string]struct {
+		reason string
+		method string
+		req    any
+		rsp    any
+		want   want
+	}{
+		"V1Response": {
+			reason: "We should count results, conditions, and desired resources in v1 responses.",
+			method: v1.FunctionRunnerService_RunFunction_FullMethodName,
+			req: &v1.RunFunctionRequest{
+				Observed: &v1.State{
+					Composite: &v1.Resource{Resource: resource.MustStructJSON(`{"kind":"XR"}`)},
+				},
+			},
+			rsp: &v1.RunFunctionResponse{
+				Results: []*v1.Result{
+					{Severity: v1.Severity_SEVERITY_FATAL, Reason: ptr.To("Boom")},
+					{Severity: v1.Severity_SEVERITY_FATAL, Reason: ptr.To("Boom")},
+				},
+				Conditions: []*v1.Condition{
+					{Type: "Ready", Status: v1.Status_STATUS_CONDITION_TRUE},
+				},
+				Desired: &v1.State{
+					Resources: map[string]*v1.Resource{"a": {}, "b": {}},
+				},
+			},
+			want: want{
+				fatal:      2,
+				ready:      1,
+				desiredSum: 2,
+			},
+		},
+		"V1Beta1Response": {
+			reason: "We should count results, conditions, and desired resources in v1beta1 responses.",
+			method: v1beta1.FunctionRunnerService_RunFunction_FullMethodName,
+			req: &v1beta1.RunFunctionRequest{
+				Observed: &v1beta1.State{
+					Composite: &v1beta1.Resource{Resource: resource.MustStructJSON(`{"kind":"XR"}`)},
+				},
+			},
+			rsp: &v1beta1.RunFunctionResponse{
+				Results: []*v1beta1.Result{
+					{Severity: v1beta1.Severity_SEVERITY_FATAL, Reason: ptr.To("Boom")},
+				},
+				Desired: &v1beta1.State{
+					Resources: map[string]*v1beta1.Resource{"a": {}},
+				},
+			},
+			want: want{
+				fatal:      1,
+				desiredSum: 1,
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			m := newResponseMetrics()
+			i := m.Interceptor()
+			info := &grpc.UnaryServerInfo{FullMethod: tc.method}
+			_, _ = i(context.Background(), tc.req, info, func(_ context.Context, _ any) (any, error) {
+				return tc.rsp, nil
+			})
+
+			fatal := metricValue(t, m.results.WithLabelValues("XR", "SEVERITY_FATAL", "Boom"))
+			if diff := cmp.Diff(tc.want.fatal, fatal); diff != "" {
+				t.Errorf("\n%s\ni(...): -want fatal results, +got fatal results:\n%s", tc.reason, diff)
+			}
+			ready := metricValue(t, m.conditions.WithLabelValues("XR", "Ready", "STATUS_CONDITION_TRUE"))
+			if diff := cmp.Diff(tc.want.ready, ready); diff != "" {
+				t.Errorf("\n%s\ni(...): -want ready conditions, +got ready conditions:\n%s", tc.reason, diff)
+			}
+
+			h := &dto.Metric{}
+			if err := m.desired.WithLabelValues("XR").(prometheus.Metric).Write(h); err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(tc.want.desiredSum, h.GetHistogram().GetSampleSum()); diff != "" {
+				t.Errorf("\n%s\ni(...): -want desired resources, +got desired resources:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
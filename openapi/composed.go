@@ -0,0 +1,126 @@
+/*
+Copyright 2026 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package openapi
+
+import (
+	"fmt"
+	"slices"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	"github.com/crossplane/function-sdk-go/errors"
+	v1 "github.com/crossplane/function-sdk-go/proto/v1"
+	"github.com/crossplane/function-sdk-go/resource"
+	"github.com/crossplane/function-sdk-go/response"
+)
+
+// Schemas of kinds of resource.
+type Schemas map[schema.GroupVersionKind]*Schema
+
+// SchemaRequirementName returns the name RequireSchemas uses to require the
+// schema of the supplied kind of resource.
+func SchemaRequirementName(gvk schema.GroupVersionKind) string {
+	return fmt.Sprintf("openapi/%s/%s", gvk.GroupVersion(), gvk.Kind)
+}
+
+// RequireSchemas adds a requirement to the supplied response for the schema of
+// each kind of the supplied desired composed resources, and returns the
+// schemas Crossplane included in the supplied request. Like other
+// requirements, schemas are required every time the Function is called.
+// Crossplane then calls the Function again with the schemas it requires.
+//
+// The returned Schemas don't include kinds Crossplane hasn't resolved yet, or
+// kinds Crossplane couldn't find a schema for.
+func RequireSchemas(req *v1.RunFunctionRequest, rsp *v1.RunFunctionResponse, dcds map[resource.Name]*resource.DesiredComposed) (Schemas, error) {
+	out := Schemas{}
+	for _, dcd := range dcds {
+		gvk := dcd.Resource.GetObjectKind().GroupVersionKind()
+		name := SchemaRequirementName(gvk)
+		response.RequireSchema(rsp, name, gvk.GroupVersion().String(), gvk.Kind)
+
+		s := req.GetRequiredSchemas()[name].GetOpenapiV3()
+		if s == nil {
+			continue
+		}
+		sc, err := New(s)
+		if err != nil {
+			return nil, errors.Wrapf(err, "cannot parse OpenAPI schema of %s %s", gvk.GroupVersion(), gvk.Kind)
+		}
+		out[gvk] = sc
+	}
+	return out, nil
+}
+
+// ValidateComposed validates each of the supplied composed resources against
+// the schema of its kind, and returns the violations of any invalid resources.
+// Resources of kinds that have no schema aren't validated.
+func (s Schemas) ValidateComposed(dcds map[resource.Name]*resource.DesiredComposed) map[resource.Name]field.ErrorList {
+	out := map[resource.Name]field.ErrorList{}
+	for name, dcd := range dcds {
+		sc, ok := s[dcd.Resource.GetObjectKind().GroupVersionKind()]
+		if !ok {
+			continue
+		}
+		if errs := sc.Validate(dcd.Resource.UnstructuredContent()); len(errs) > 0 {
+			out[name] = errs
+		}
+	}
+	return out
+}
+
+// ValidateDesiredComposed requires the schema of each kind of the supplied
+// desired composed resources, and validates each resource against its schema
+// once Crossplane supplies it. It adds a result with the supplied severity to
+// the supplied response for each invalid resource. The severity should be
+// either v1.Severity_SEVERITY_WARNING or v1.Severity_SEVERITY_FATAL. It returns
+// true if it didn't find any invalid resources.
+//
+// Call ValidateDesiredComposed before setting the desired composed resources
+// in the response, so a Function can decide whether to send invalid resources
+// to Crossplane.
+func ValidateDesiredComposed(req *v1.RunFunctionRequest, rsp *v1.RunFunctionResponse, dcds map[resource.Name]*resource.DesiredComposed, severity v1.Severity) (bool, error) {
+	s, err := RequireSchemas(req, rsp, dcds)
+	if err != nil {
+		return false, err
+	}
+	invalid := s.ValidateComposed(dcds)
+	ReportInvalidComposed(rsp, invalid, severity)
+	return len(invalid) == 0, nil
+}
+
+// ReportInvalidComposed adds a result with the supplied severity to the
+// supplied response for each of the supplied invalid composed resources, in
+// order of resource name. Each result's message names the resource and
+// describes every way in which it's invalid. The severity should be either
+// v1.Severity_SEVERITY_WARNING or v1.Severity_SEVERITY_FATAL.
+func ReportInvalidComposed(rsp *v1.RunFunctionResponse, invalid map[resource.Name]field.ErrorList, severity v1.Severity) {
+	names := make([]resource.Name, 0, len(invalid))
+	for name := range invalid {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+
+	for _, name := range names {
+		err := errors.Wrapf(invalid[name].ToAggregate(), "desired composed resource %q is invalid", name)
+		if severity == v1.Severity_SEVERITY_FATAL {
+			response.Fatal(rsp, err)
+			continue
+		}
+		response.Warning(rsp, err)
+	}
+}
@@ -0,0 +1,165 @@
+/*
+Copyright 2026 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package openapi
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"google.golang.org/protobuf/testing/protocmp"
+
+	v1 "github.com/crossplane/function-sdk-go/proto/v1"
+	"github.com/crossplane/function-sdk-go/resource"
+	"github.com/crossplane/function-sdk-go/resource/composed"
+)
+
+const bucketSchema = `{
+	"type": "object",
+	"properties": {
+		"apiVersion": {"type": "string"},
+		"kind": {"type": "string"},
+		"metadata": {"type": "object"},
+		"spec": {
+			"type": "object",
+			"properties": {
+				"forProvider": {
+					"type": "object",
+					"properties": {
+						"region": {"type": "string", "pattern": "^[a-z]+-[a-z]+-[0-9]$"},
+						"acl": {"type": "string", "enum": ["private", "public-read"]}
+					},
+					"required": ["region"]
+				}
+			}
+		}
+	}
+}`
+
+func MustComposed(t *testing.T, j string) *resource.DesiredComposed {
+	t.Helper()
+	cd := composed.New()
+	if err := resource.AsObject(resource.MustStructJSON(j), cd); err != nil {
+		t.Fatalf("resource.AsObject(...): %v", err)
+	}
+	return &resource.DesiredComposed{Resource: cd}
+}
+
+func TestValidateDesiredComposed(t *testing.T) {
+	dcds := map[resource.Name]*resource.DesiredComposed{
+		"good-bucket": MustComposed(t, `{"apiVersion": "s3.example.org/v1", "kind": "Bucket", "metadata": {"name": "good"}, "spec": {"forProvider": {"region": "us-east-1"}}}`),
+		"bad-bucket":  MustComposed(t, `{"apiVersion": "s3.example.org/v1", "kind": "Bucket", "spec": {"forProvider": {"acl": "secret", "regoin": "us-east-1"}}}`),
+		"config":      MustComposed(t, `{"apiVersion": "v1", "kind": "ConfigMap", "data": {"a": "b"}}`),
+	}
+
+	requirements := &v1.Requirements{
+		Schemas: map[string]*v1.SchemaSelector{
+			"openapi/s3.example.org/v1/Bucket": {ApiVersion: "s3.example.org/v1", Kind: "Bucket"},
+			"openapi/v1/ConfigMap":             {ApiVersion: "v1", Kind: "ConfigMap"},
+		},
+	}
+
+	type args struct {
+		req      *v1.RunFunctionRequest
+		severity v1.Severity
+	}
+	type want struct {
+		valid bool
+		rsp   *v1.RunFunctionResponse
+		err   error
+	}
+
+	cases := map[string]struct {
+		reason string
+		args   args
+		want   want
+	}{
+		"SchemasNotResolved": {
+			reason: "We should require the schema of each kind of resource, and not validate resources whose schemas we don't have.",
+			args: args{
+				req:      &v1.RunFunctionRequest{},
+				severity: v1.Severity_SEVERITY_WARNING,
+			},
+			want: want{
+				valid: true,
+				rsp:   &v1.RunFunctionResponse{Requirements: requirements},
+			},
+		},
+		"Warning": {
+			reason: "We should add a warning result for each invalid resource, and skip kinds Crossplane couldn't find a schema for.",
+			args: args{
+				req: &v1.RunFunctionRequest{
+					RequiredSchemas: map[string]*v1.Schema{
+						"openapi/s3.example.org/v1/Bucket": {OpenapiV3: resource.MustStructJSON(bucketSchema)},
+						"openapi/v1/ConfigMap":             {},
+					},
+				},
+				severity: v1.Severity_SEVERITY_WARNING,
+			},
+			want: want{
+				valid: false,
+				rsp: &v1.RunFunctionResponse{
+					Requirements: requirements,
+					Results: []*v1.Result{{
+						Severity: v1.Severity_SEVERITY_WARNING,
+						Message:  `desired composed resource "bad-bucket" is invalid: [spec.forProvider.acl: Unsupported value: "secret": supported values: "private", "public-read", spec.forProvider.region: Required value, spec.forProvider.regoin: Forbidden: unknown field]`,
+						Target:   v1.Target_TARGET_COMPOSITE.Enum(),
+					}},
+				},
+			},
+		},
+		"Fatal": {
+			reason: "We should add a fatal result for each invalid resource if asked to.",
+			args: args{
+				req: &v1.RunFunctionRequest{
+					RequiredSchemas: map[string]*v1.Schema{
+						"openapi/s3.example.org/v1/Bucket": {OpenapiV3: resource.MustStructJSON(bucketSchema)},
+					},
+				},
+				severity: v1.Severity_SEVERITY_FATAL,
+			},
+			want: want{
+				valid: false,
+				rsp: &v1.RunFunctionResponse{
+					Requirements: requirements,
+					Results: []*v1.Result{{
+						Severity: v1.Severity_SEVERITY_FATAL,
+						Message:  `desired composed resource "bad-bucket" is invalid: [spec.forProvider.acl: Unsupported value: "secret": supported values: "private", "public-read", spec.forProvider.region: Required value, spec.forProvider.regoin: Forbidden: unknown field]`,
+						Target:   v1.Target_TARGET_COMPOSITE.Enum(),
+					}},
+				},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			rsp := &v1.RunFunctionResponse{}
+			valid, err := ValidateDesiredComposed(tc.args.req, rsp, dcds, tc.args.severity)
+
+			if diff := cmp.Diff(tc.want.valid, valid); diff != "" {
+				t.Errorf("\n%s\nValidateDesiredComposed(...): -want valid, +got valid:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.rsp, rsp, protocmp.Transform()); diff != "" {
+				t.Errorf("\n%s\nValidateDesiredComposed(...): -want rsp, +got rsp:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.err, err, cmpopts.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nValidateDesiredComposed(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
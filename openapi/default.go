@@ -0,0 +1,74 @@
+/*
+Copyright 2026 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package openapi
+
+import (
+	structuralschema "k8s.io/apiextensions-apiserver/pkg/apiserver/schema"
+	"k8s.io/apiextensions-apiserver/pkg/apiserver/schema/defaulting"
+	"k8s.io/apiextensions-apiserver/pkg/apiserver/schema/pruning"
+
+	"github.com/crossplane/function-sdk-go/resource"
+	"github.com/crossplane/function-sdk-go/resource/composed"
+)
+
+// Default sets the default values the schema specifies for any fields that
+// are missing from the supplied object, like the Kubernetes API server does.
+// Fields that are null are also defaulted, unless the schema says they're
+// nullable.
+func (s *Schema) Default(obj map[string]any) {
+	defaulting.Default(obj, s.structural)
+}
+
+// Prune removes any fields the schema doesn't specify from the supplied
+// object, like the Kubernetes API server does. Fields are kept if the schema
+// allows unknown fields using additionalProperties or
+// x-kubernetes-preserve-unknown-fields. The apiVersion, kind, and metadata
+// fields of the object, and of any embedded resources, are never pruned.
+//
+// Prune returns the paths of the fields it removed, in order.
+func (s *Schema) Prune(obj map[string]any) []string {
+	opts := structuralschema.UnknownFieldPathOptions{TrackUnknownFieldPaths: true}
+	return pruning.PruneWithOptions(obj, s.structural, true, opts)
+}
+
+// DefaultAndPrune prunes then defaults the supplied composed resource, like
+// the Kubernetes API server would when the resource is applied. It returns the
+// paths of the fields it pruned, in order. Pruned fields are usually a mistake;
+// for example a misspelled field name.
+func (s *Schema) DefaultAndPrune(cd *composed.Unstructured) []string {
+	pruned := s.Prune(cd.Object)
+	s.Default(cd.Object)
+	return pruned
+}
+
+// DefaultAndPruneComposed prunes then defaults each of the supplied composed
+// resources using the schema of its kind. Resources of kinds that have no
+// schema are left unchanged. It returns the paths of the fields it pruned from
+// each resource, omitting resources it didn't prune any fields from.
+func (s Schemas) DefaultAndPruneComposed(dcds map[resource.Name]*resource.DesiredComposed) map[resource.Name][]string {
+	out := map[resource.Name][]string{}
+	for name, dcd := range dcds {
+		sc, ok := s[dcd.Resource.GetObjectKind().GroupVersionKind()]
+		if !ok {
+			continue
+		}
+		if pruned := sc.DefaultAndPrune(dcd.Resource); len(pruned) > 0 {
+			out[name] = pruned
+		}
+	}
+	return out
+}
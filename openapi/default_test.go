@@ -0,0 +1,216 @@
+/*
+Copyright 2026 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package openapi
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"google.golang.org/protobuf/testing/protocmp"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/crossplane/function-sdk-go/resource"
+)
+
+const defaultingSchema = `
+type: object
+properties:
+  apiVersion: {type: string}
+  kind: {type: string}
+  metadata: {type: object}
+  spec:
+    type: object
+    properties:
+      replicas: {type: integer, default: 1}
+      ratio: {type: number, default: 0.5}
+      region: {type: string, default: us-east-1}
+      zone: {type: string, nullable: true, default: a}
+      tags:
+        type: object
+        additionalProperties:
+          type: object
+          properties:
+            value: {type: string, default: none}
+      ports:
+        type: array
+        items:
+          type: object
+          properties:
+            port: {type: integer}
+            protocol: {type: string, default: TCP}
+      config:
+        type: object
+        x-kubernetes-preserve-unknown-fields: true
+        properties:
+          enabled: {type: boolean, default: true}
+      template:
+        type: object
+        x-kubernetes-embedded-resource: true
+        properties:
+          spec:
+            type: object
+            properties:
+              size: {type: string}
+`
+
+func TestDefaultAndPrune(t *testing.T) {
+	type want struct {
+		obj    string
+		pruned []string
+	}
+
+	cases := map[string]struct {
+		reason string
+		obj    string
+		want   want
+	}{
+		"Defaulted": {
+			reason: "Missing fields should be defaulted, including nested fields in maps and arrays.",
+			obj: `{
+				"apiVersion": "example.org/v1",
+				"kind": "Cool",
+				"metadata": {"name": "cool"},
+				"spec": {
+					"region": "eu-west-1",
+					"zone": null,
+					"tags": {"a": {}},
+					"ports": [{"port": 80}],
+					"config": {}
+				}
+			}`,
+			want: want{
+				obj: `{
+					"apiVersion": "example.org/v1",
+					"kind": "Cool",
+					"metadata": {"name": "cool"},
+					"spec": {
+						"replicas": 1,
+						"ratio": 0.5,
+						"region": "eu-west-1",
+						"zone": null,
+						"tags": {"a": {"value": "none"}},
+						"ports": [{"port": 80, "protocol": "TCP"}],
+						"config": {"enabled": true}
+					}
+				}`,
+			},
+		},
+		"NullDefaulted": {
+			reason: "Null fields that aren't nullable should be defaulted.",
+			obj:    `{"spec": {"replicas": null, "ratio": 1, "region": "eu-west-1"}}`,
+			want: want{
+				obj:    `{"spec": {"replicas": 1, "ratio": 1, "region": "eu-west-1", "zone": "a"}}`,
+				pruned: []string{},
+			},
+		},
+		"Pruned": {
+			reason: "Unknown fields should be pruned and reported, except where the schema preserves them.",
+			obj: `{
+				"apiVersion": "example.org/v1",
+				"kind": "Cool",
+				"metadata": {"name": "cool", "labels": {"a": "b"}},
+				"spec": {
+					"replicas": 3,
+					"ratio": 1,
+					"region": "eu-west-1",
+					"regoin": "eu-west-1",
+					"tags": {"a": {"value": "b", "vaule": "c"}},
+					"ports": [{"port": 80, "protocol": "UDP", "name": "http"}],
+					"config": {"anything": "goes"},
+					"template": {"apiVersion": "v1", "kind": "Thing", "metadata": {"name": "t"}, "spec": {"size": "L", "colour": "red"}}
+				},
+				"status": {"ready": true}
+			}`,
+			want: want{
+				obj: `{
+					"apiVersion": "example.org/v1",
+					"kind": "Cool",
+					"metadata": {"name": "cool", "labels": {"a": "b"}},
+					"spec": {
+						"replicas": 3,
+						"ratio": 1,
+						"region": "eu-west-1",
+						"zone": "a",
+						"tags": {"a": {"value": "b"}},
+						"ports": [{"port": 80, "protocol": "UDP"}],
+						"config": {"anything": "goes", "enabled": true},
+						"template": {"apiVersion": "v1", "kind": "Thing", "metadata": {"name": "t"}, "spec": {"size": "L"}}
+					}
+				}`,
+				pruned: []string{
+					"spec.ports[0].name",
+					"spec.regoin",
+					"spec.tags.a.vaule",
+					"spec.template.spec.colour",
+					"status",
+				},
+			},
+		},
+	}
+
+	s, err := FromYAML([]byte(defaultingSchema))
+	if err != nil {
+		t.Fatalf("FromYAML(...): %v", err)
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			cd := MustComposed(t, tc.obj).Resource
+			pruned := s.DefaultAndPrune(cd)
+
+			// Compare as structs, which don't distinguish integers from
+			// floats, like the desired resources sent to Crossplane.
+			want := resource.MustStructJSON(tc.want.obj)
+			got, err := resource.AsStruct(cd)
+			if err != nil {
+				t.Fatalf("resource.AsStruct(...): %v", err)
+			}
+			if diff := cmp.Diff(want, got, protocmp.Transform()); diff != "" {
+				t.Errorf("\n%s\nDefaultAndPrune(...): -want, +got:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.pruned, pruned, cmpopts.EquateEmpty()); diff != "" {
+				t.Errorf("\n%s\nDefaultAndPrune(...): -want pruned, +got pruned:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestDefaultAndPruneComposed(t *testing.T) {
+	s := Schemas{
+		schema.GroupVersionKind{Group: "example.org", Version: "v1", Kind: "Cool"}: Must(FromYAML([]byte(defaultingSchema))),
+	}
+
+	dcds := map[resource.Name]*resource.DesiredComposed{
+		"cool":   MustComposed(t, `{"apiVersion": "example.org/v1", "kind": "Cool", "spec": {"regoin": "eu-west-1"}}`),
+		"tidy":   MustComposed(t, `{"apiVersion": "example.org/v1", "kind": "Cool", "spec": {}}`),
+		"config": MustComposed(t, `{"apiVersion": "v1", "kind": "ConfigMap", "data": {"a": "b"}}`),
+	}
+
+	pruned := s.DefaultAndPruneComposed(dcds)
+
+	want := map[resource.Name][]string{"cool": {"spec.regoin"}}
+	if diff := cmp.Diff(want, pruned); diff != "" {
+		t.Errorf("DefaultAndPruneComposed(...): -want pruned, +got pruned:\n%s", diff)
+	}
+	if diff := cmp.Diff("us-east-1", dcds["tidy"].Resource.Object["spec"].(map[string]any)["region"]); diff != "" {
+		t.Errorf("DefaultAndPruneComposed(...): -want default, +got default:\n%s", diff)
+	}
+	if diff := cmp.Diff(map[string]any{"a": "b"}, dcds["config"].Resource.Object["data"]); diff != "" {
+		t.Errorf("DefaultAndPruneComposed(...): resources without a schema should be unchanged: -want, +got:\n%s", diff)
+	}
+}
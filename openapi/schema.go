@@ -0,0 +1,150 @@
+/*
+Copyright 2026 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package openapi validates, defaults, and prunes objects using the OpenAPI v3
+// schemas used by Kubernetes CustomResourceDefinitions. It's built on the
+// Kubernetes API server's own CRD schema implementation, so objects are
+// validated the same way the API server validates custom resources.
+//
+// Functions can use this package to validate their input against their input
+// CRD, and to validate, default, and prune desired composed resources using the
+// schemas Crossplane supplies in RunFunctionRequest.required_schemas.
+package openapi
+
+import (
+	"encoding/json"
+	"math"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/types/known/structpb"
+	"k8s.io/apiextensions-apiserver/pkg/apis/apiextensions"
+	extv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	structuralschema "k8s.io/apiextensions-apiserver/pkg/apiserver/schema"
+	"k8s.io/apiextensions-apiserver/pkg/apiserver/validation"
+	"sigs.k8s.io/yaml"
+
+	"github.com/crossplane/function-sdk-go/errors"
+	"github.com/crossplane/function-sdk-go/request"
+)
+
+var _ request.Validator = &Schema{}
+
+// A Schema is an OpenAPI v3 schema, like a CRD's openAPIV3Schema. It must be a
+// structural schema, like Kubernetes requires CRD schemas to be.
+type Schema struct {
+	structural *structuralschema.Structural
+	validator  validation.SchemaValidator
+}
+
+// New returns a Schema from the supplied OpenAPI v3 schema, like those returned
+// by request.GetRequiredSchema.
+func New(s *structpb.Struct) (*Schema, error) {
+	j, err := protojson.Marshal(s)
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot marshal OpenAPI schema to JSON")
+	}
+	return FromJSON(j)
+}
+
+// FromJSON returns a Schema from the supplied OpenAPI v3 schema JSON.
+func FromJSON(j []byte) (*Schema, error) {
+	props := &extv1.JSONSchemaProps{}
+	if err := json.Unmarshal(j, props); err != nil {
+		return nil, errors.Wrap(err, "cannot unmarshal OpenAPI schema")
+	}
+	return fromProps(props)
+}
+
+// FromYAML returns a Schema from the supplied OpenAPI v3 schema YAML.
+func FromYAML(y []byte) (*Schema, error) {
+	j, err := yaml.YAMLToJSON(y)
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot convert OpenAPI schema YAML to JSON")
+	}
+	return FromJSON(j)
+}
+
+// FromCRD returns a Schema from the supplied version of the supplied
+// CustomResourceDefinition YAML. Use it with the input CRD that controller-gen
+// generates from a Function's input Go types, for example by embedding it in
+// the Function's binary.
+func FromCRD(y []byte, version string) (*Schema, error) {
+	crd := &extv1.CustomResourceDefinition{}
+	if err := yaml.Unmarshal(y, crd); err != nil {
+		return nil, errors.Wrap(err, "cannot unmarshal CustomResourceDefinition")
+	}
+	for _, v := range crd.Spec.Versions {
+		if v.Name != version {
+			continue
+		}
+		if v.Schema == nil || v.Schema.OpenAPIV3Schema == nil {
+			return nil, errors.Errorf("version %q of CustomResourceDefinition has no OpenAPI schema", version)
+		}
+		return fromProps(v.Schema.OpenAPIV3Schema)
+	}
+	return nil, errors.Errorf("CustomResourceDefinition has no version %q", version)
+}
+
+// Must returns the supplied Schema, or panics if the supplied error isn't nil.
+// It's useful for initializing a package level variable from an embedded CRD,
+// for example:
+//
+//	var schema = openapi.Must(openapi.FromCRD(crd, "v1beta1"))
+func Must(s *Schema, err error) *Schema {
+	if err != nil {
+		panic(err)
+	}
+	return s
+}
+
+// fromProps returns a Schema from the supplied schema.
+func fromProps(in *extv1.JSONSchemaProps) (*Schema, error) {
+	props := &apiextensions.JSONSchemaProps{}
+	if err := extv1.Convert_v1_JSONSchemaProps_To_apiextensions_JSONSchemaProps(in, props, nil); err != nil {
+		return nil, errors.Wrap(err, "cannot convert OpenAPI schema")
+	}
+	ss, err := structuralschema.NewStructural(props)
+	if err != nil {
+		return nil, errors.Wrap(err, "OpenAPI schema is not a structural schema")
+	}
+	v, _, err := validation.NewSchemaValidator(props)
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot create OpenAPI schema validator")
+	}
+	return &Schema{structural: ss, validator: v}, nil
+}
+
+// normalize numbers in the supplied JSON value to int64 if they're integers, or
+// float64 otherwise, like the Kubernetes API server does when it decodes an
+// object. Objects converted from a protobuf Struct represent every number as a
+// float64.
+func normalize(v any) any {
+	switch tv := v.(type) {
+	case float64:
+		if tv == math.Trunc(tv) && tv >= math.MinInt64 && tv < math.MaxInt64 {
+			return int64(tv)
+		}
+	case map[string]any:
+		for k, e := range tv {
+			tv[k] = normalize(e)
+		}
+	case []any:
+		for i, e := range tv {
+			tv[i] = normalize(e)
+		}
+	}
+	return v
+}
@@ -0,0 +1,99 @@
+/*
+Copyright 2026 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package openapi
+
+import (
+	_ "embed"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+
+	"github.com/crossplane/function-sdk-go/resource"
+)
+
+//go:embed testdata/input.yaml
+var inputCRD []byte
+
+func TestFromCRD(t *testing.T) {
+	type want struct {
+		violations int
+		err        error
+	}
+
+	cases := map[string]struct {
+		reason  string
+		version string
+		obj     string
+		want    want
+	}{
+		"Valid": {
+			reason:  "We should extract the schema for the supplied version.",
+			version: "v1beta1",
+			obj:     `{"apiVersion": "example.org/v1beta1", "kind": "Input", "region": "us-east-1", "names": ["a"]}`,
+			want:    want{violations: 0},
+		},
+		"Invalid": {
+			reason:  "The extracted schema should validate objects.",
+			version: "v1beta1",
+			obj:     `{"apiVersion": "example.org/v1beta1", "kind": "Input", "replicas": 0, "names": ["A"]}`,
+			want:    want{violations: 3},
+		},
+		"NoSuchVersion": {
+			reason:  "We should return an error if the CRD doesn't have the supplied version.",
+			version: "v1",
+			want:    want{err: cmpopts.AnyError},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			s, err := FromCRD(inputCRD, tc.version)
+			if diff := cmp.Diff(tc.want.err, err, cmpopts.EquateErrors()); diff != "" {
+				t.Fatalf("\n%s\nFromCRD(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+			if err != nil {
+				return
+			}
+
+			errs := s.Validate(resource.MustStructJSON(tc.obj).AsMap())
+			if diff := cmp.Diff(tc.want.violations, len(errs)); diff != "" {
+				t.Errorf("\n%s\nValidate(...): -want violations, +got violations:\n%s\n%v", tc.reason, diff, errs)
+			}
+		})
+	}
+}
+
+func TestNew(t *testing.T) {
+	s, err := New(resource.MustStructJSON(`{
+		"type": "object",
+		"properties": {
+			"spec": {
+				"type": "object",
+				"properties": {"replicas": {"type": "integer", "maximum": 3}}
+			}
+		}
+	}`))
+	if err != nil {
+		t.Fatalf("New(...): %v", err)
+	}
+
+	errs := s.Validate(resource.MustStructJSON(`{"spec": {"replicas": 4}}`).AsMap())
+	if diff := cmp.Diff(1, len(errs)); diff != "" {
+		t.Errorf("New(...): Validate(...): -want violations, +got violations:\n%s\n%v", diff, errs)
+	}
+}
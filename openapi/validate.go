@@ -0,0 +1,42 @@
+/*
+Copyright 2026 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package openapi
+
+import (
+	"k8s.io/apiextensions-apiserver/pkg/apiserver/validation"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+// Validate the supplied object against the schema, the same way the Kubernetes
+// API server validates a custom resource. Validate returns all of the ways in
+// which the object violates the schema, each with the path to the field that
+// violates it.
+//
+// Unlike the API server, which prunes unknown fields, Validate returns an error
+// for fields the schema doesn't specify, like the API server does when asked
+// for strict field validation. Fields are allowed if the schema allows them
+// using additionalProperties or x-kubernetes-preserve-unknown-fields. Use the
+// returned list's ToAggregate method to combine the violations into one error.
+func (s *Schema) Validate(obj map[string]any) field.ErrorList {
+	obj = normalize(runtime.DeepCopyJSON(obj)).(map[string]any) //nolint:forcetypeassert // normalize always returns a map given a map.
+	errs := validation.ValidateCustomResource(nil, obj, s.validator)
+	for _, p := range s.Prune(obj) {
+		errs = append(errs, field.Forbidden(field.NewPath(p), "unknown field"))
+	}
+	return errs
+}
@@ -0,0 +1,294 @@
+/*
+Copyright 2026 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package openapi
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	v1 "github.com/crossplane/function-sdk-go/proto/v1"
+	"github.com/crossplane/function-sdk-go/request"
+	"github.com/crossplane/function-sdk-go/resource"
+)
+
+func TestValidate(t *testing.T) {
+	cases := map[string]struct {
+		reason string
+		schema string
+		obj    string
+		want   []string
+	}{
+		"Valid": {
+			reason: "An object that matches the schema should be valid.",
+			schema: `
+type: object
+properties:
+  spec:
+    type: object
+    properties:
+      region: {type: string, enum: [us-east-1, eu-west-1]}
+      replicas: {type: integer, minimum: 1}
+      tags: {type: object, additionalProperties: {type: string}}
+    required: [region]
+`,
+			obj: `{"apiVersion": "example.org/v1", "kind": "XR", "metadata": {"name": "cool"}, "spec": {"region": "us-east-1", "replicas": 3, "tags": {"a": "b"}}}`,
+		},
+		"WrongTypes": {
+			reason: "We should return every field that has the wrong type.",
+			schema: `
+type: object
+properties:
+  spec:
+    type: object
+    properties:
+      name: {type: string}
+      replicas: {type: integer}
+      ratio: {type: number}
+      enabled: {type: boolean}
+      items: {type: array, items: {type: string}}
+`,
+			obj: `{"spec": {"name": 1, "replicas": 1.5, "ratio": "1", "enabled": "yes", "items": [1]}}`,
+			want: []string{
+				`<nil>: Invalid value: "": Checked value must be of type integer (default format) in spec.replicas`,
+				`spec.enabled: Invalid value: "string": spec.enabled in body must be of type boolean: "string"`,
+				`spec.items[0]: Invalid value: "integer": spec.items[0] in body must be of type string: "integer"`,
+				`spec.name: Invalid value: "integer": spec.name in body must be of type string: "integer"`,
+				`spec.ratio: Invalid value: "string": spec.ratio in body must be of type number: "string"`,
+				`spec.replicas: Invalid value: "number": spec.replicas in body must be of type integer: "number"`,
+			},
+		},
+		"RequiredAndUnknown": {
+			reason: "We should return missing required fields and unknown fields.",
+			schema: `
+type: object
+properties:
+  spec:
+    type: object
+    properties:
+      region: {type: string}
+    required: [region]
+`,
+			obj: `{"spec": {"regoin": "us-east-1"}}`,
+			want: []string{
+				`spec.region: Required value`,
+				`spec.regoin: Forbidden: unknown field`,
+			},
+		},
+		"EnumAndPattern": {
+			reason: "We should return values that aren't in an enum, or don't match a pattern.",
+			schema: `
+type: object
+properties:
+  region: {type: string, enum: [us-east-1, eu-west-1]}
+  name: {type: string, pattern: "^[a-z]+$"}
+`,
+			obj: `{"region": "us-west-2", "name": "Cool"}`,
+			want: []string{
+				`name: Invalid value: "Cool": name in body should match '^[a-z]+$'`,
+				`region: Unsupported value: "us-west-2": supported values: "us-east-1", "eu-west-1"`,
+			},
+		},
+		"Bounds": {
+			reason: "We should return values that are out of bounds.",
+			schema: `
+type: object
+properties:
+  replicas: {type: integer, minimum: 1, maximum: 3}
+  ratio: {type: number, minimum: 0, exclusiveMinimum: true}
+  name: {type: string, minLength: 2, maxLength: 3}
+  items: {type: array, maxItems: 1, items: {type: string}}
+`,
+			obj: `{"replicas": 4, "ratio": 0, "name": "coolest", "items": ["a", "b"]}`,
+			want: []string{
+				`items: Too many: 2: must have at most 1 item`,
+				`name: Too long: may not be more than 3 bytes`,
+				`ratio: Invalid value: 0: ratio in body should be greater than 0`,
+				`replicas: Invalid value: 4: replicas in body should be less than or equal to 3`,
+			},
+		},
+		"AdditionalProperties": {
+			reason: "We should return the path to an invalid value of a map, and allow any key.",
+			schema: `
+type: object
+properties:
+  spec:
+    type: object
+    properties:
+      tags: {type: object, additionalProperties: {type: string, maxLength: 3}}
+`,
+			obj: `{"spec": {"tags": {"a": "b", "example.org/team": "platform"}}}`,
+			want: []string{
+				`spec.tags.example.org/team: Too long: may not be more than 3 bytes`,
+			},
+		},
+		"PreserveUnknownFields": {
+			reason: "Unknown fields should be allowed where the schema preserves them.",
+			schema: `
+type: object
+properties:
+  spec:
+    type: object
+    x-kubernetes-preserve-unknown-fields: true
+    properties:
+      region: {type: string}
+`,
+			obj: `{"spec": {"region": 1, "anything": {"goes": true}}}`,
+			want: []string{
+				`spec.region: Invalid value: "integer": spec.region in body must be of type string: "integer"`,
+			},
+		},
+		"EmbeddedResource": {
+			reason: "The metadata of embedded resources should be allowed even if the schema doesn't specify it.",
+			schema: `
+type: object
+properties:
+  template:
+    type: object
+    x-kubernetes-embedded-resource: true
+    properties:
+      spec: {type: object, properties: {region: {type: string}}}
+`,
+			obj: `{"template": {"apiVersion": "v1", "kind": "Cool", "metadata": {"name": "cool"}, "spec": {"region": "us-east-1", "zone": "a"}}}`,
+			want: []string{
+				`template.spec.zone: Forbidden: unknown field`,
+			},
+		},
+		"IntOrStringAndNullable": {
+			reason: "Int-or-string fields should allow integers and strings, and nullable fields should allow null.",
+			schema: `
+type: object
+properties:
+  port: {x-kubernetes-int-or-string: true}
+  other: {x-kubernetes-int-or-string: true}
+  name: {type: string, nullable: true}
+  region: {type: string}
+`,
+			obj: `{"port": 80, "other": true, "name": null, "region": null}`,
+			want: []string{
+				`other: Invalid value: "boolean": other in body must be of type integer,string: "boolean"`,
+				`region: Invalid value: "null": region in body must be of type string: "null"`,
+			},
+		},
+		"OneOf": {
+			reason: "Values should match exactly one oneOf schema, without unknown field checks.",
+			schema: `
+type: object
+properties:
+  source:
+    type: object
+    properties:
+      secret: {type: string}
+      configMap: {type: string}
+    oneOf:
+    - required: [secret]
+    - required: [configMap]
+`,
+			obj: `{"source": {"secret": "a", "configMap": "b"}}`,
+			want: []string{
+				`<nil>: Invalid value: "": "source" must validate one and only one schema (oneOf). Found 2 valid alternatives`,
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			s, err := FromYAML([]byte(tc.schema))
+			if err != nil {
+				t.Fatalf("FromYAML(...): %v", err)
+			}
+
+			errs := s.Validate(resource.MustStructJSON(tc.obj).AsMap())
+			got := make([]string, len(errs))
+			for i, e := range errs {
+				got[i] = e.Error()
+			}
+
+			if diff := cmp.Diff(tc.want, got, cmpopts.EquateEmpty(), cmpopts.SortSlices(func(a, b string) bool { return a < b })); diff != "" {
+				t.Errorf("\n%s\nValidate(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestGetValidatedInput(t *testing.T) {
+	s := Must(FromYAML([]byte(`
+type: object
+properties:
+  apiVersion: {type: string}
+  kind: {type: string}
+  region: {type: string, enum: [us-east-1, eu-west-1]}
+  replicas: {type: integer, minimum: 1}
+required: [region]
+`)))
+
+	type want struct {
+		in  *unstructured.Unstructured
+		err string
+	}
+
+	cases := map[string]struct {
+		reason string
+		req    *v1.RunFunctionRequest
+		want   want
+	}{
+		"Valid": {
+			reason: "Input that matches the Schema should be loaded into the supplied object.",
+			req: &v1.RunFunctionRequest{
+				Input: resource.MustStructJSON(`{"apiVersion": "example.org/v1", "kind": "Input", "region": "us-east-1", "replicas": 2}`),
+			},
+			want: want{
+				in: &unstructured.Unstructured{Object: map[string]any{
+					"apiVersion": "example.org/v1",
+					"kind":       "Input",
+					"region":     "us-east-1",
+					"replicas":   float64(2),
+				}},
+			},
+		},
+		"Invalid": {
+			reason: "We should return every violation of the Schema in a single error.",
+			req: &v1.RunFunctionRequest{
+				Input: resource.MustStructJSON(`{"apiVersion": "example.org/v1", "kind": "Input", "replicas": 0, "regoin": "us-east-1"}`),
+			},
+			want: want{
+				in:  &unstructured.Unstructured{},
+				err: "invalid function input: [replicas: Invalid value: 0: replicas in body should be greater than or equal to 1, region: Required value, regoin: Forbidden: unknown field]",
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			in := &unstructured.Unstructured{}
+			err := request.GetValidatedInput(tc.req, s, in)
+
+			if diff := cmp.Diff(tc.want.in, in); diff != "" {
+				t.Errorf("\n%s\nrequest.GetValidatedInput(...): -want, +got:\n%s", tc.reason, diff)
+			}
+			got := ""
+			if err != nil {
+				got = err.Error()
+			}
+			if diff := cmp.Diff(tc.want.err, got); diff != "" {
+				t.Errorf("\n%s\nrequest.GetValidatedInput(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,293 @@
+/*
+Copyright 2026 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package patch patches composite and composed resources, using the same
+// patches and transforms as a patch-and-transform Composition.
+package patch
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/crossplane/crossplane-runtime/v2/pkg/fieldpath"
+
+	fncontext "github.com/crossplane/function-sdk-go/context"
+	"github.com/crossplane/function-sdk-go/errors"
+	v1 "github.com/crossplane/function-sdk-go/proto/v1"
+	"github.com/crossplane/function-sdk-go/request"
+	"github.com/crossplane/function-sdk-go/resource"
+	"github.com/crossplane/function-sdk-go/resource/composed"
+	"github.com/crossplane/function-sdk-go/resource/composite"
+)
+
+// Resources a Patch reads from and writes to. Like a patch-and-transform
+// Composition, patches read from observed resources and the environment, and
+// write to desired resources.
+type Resources struct {
+	// Environment read by FromEnvironmentFieldPath patches. Use GetEnvironment
+	// to get it from the Function's context.
+	Environment *unstructured.Unstructured
+
+	// ObservedComposite resource read by FromCompositeFieldPath and
+	// CombineFromComposite patches.
+	ObservedComposite *composite.Unstructured
+
+	// DesiredComposite resource written by ToCompositeFieldPath patches.
+	DesiredComposite *composite.Unstructured
+
+	// ObservedComposed resource read by ToCompositeFieldPath patches. It's nil
+	// if the composed resource hasn't been created yet.
+	ObservedComposed *composed.Unstructured
+
+	// DesiredComposed resource written by FromCompositeFieldPath,
+	// CombineFromComposite, and FromEnvironmentFieldPath patches.
+	DesiredComposed *composed.Unstructured
+}
+
+// A Patch copies a value from one of the supplied resources to another.
+type Patch func(r Resources) error
+
+// Patches of each composed resource, by name.
+type Patches map[resource.Name][]Patch
+
+// A Policy determines what a Patch does when a field path it reads from
+// doesn't exist.
+type Policy string
+
+// Patch policies.
+const (
+	// PolicyOptional patches skip patching when a field path they read from
+	// doesn't exist. This is the default.
+	PolicyOptional Policy = "Optional"
+
+	// PolicyRequired patches return an error when a field path they read
+	// from doesn't exist.
+	PolicyRequired Policy = "Required"
+)
+
+type options struct {
+	policy     Policy
+	transforms []Transform
+}
+
+// An Option configures a Patch.
+type Option func(o *options)
+
+// WithPolicy configures what a Patch does when a field path it reads from
+// doesn't exist. Patches are PolicyOptional by default.
+func WithPolicy(p Policy) Option {
+	return func(o *options) {
+		o.policy = p
+	}
+}
+
+// WithTransforms configures the transforms a Patch applies, in order, to the
+// value it reads before writing it.
+func WithTransforms(ts ...Transform) Option {
+	return func(o *options) {
+		o.transforms = append(o.transforms, ts...)
+	}
+}
+
+func newOptions(opts ...Option) *options {
+	o := &options{policy: PolicyOptional}
+	for _, fn := range opts {
+		fn(o)
+	}
+	return o
+}
+
+// FromCompositeFieldPath returns a Patch that copies the value of the supplied
+// field path of the observed composite resource to the supplied field path of
+// the desired composed resource. The field path is copied to the same field
+// path if to is empty.
+func FromCompositeFieldPath(from, to string, opts ...Option) Patch {
+	o := newOptions(opts...)
+	return func(r Resources) error {
+		return o.patch(content(r.ObservedComposite), content(r.DesiredComposed), from, to)
+	}
+}
+
+// ToCompositeFieldPath returns a Patch that copies the value of the supplied
+// field path of the observed composed resource to the supplied field path of
+// the desired composite resource. The field path is copied to the same field
+// path if to is empty.
+func ToCompositeFieldPath(from, to string, opts ...Option) Patch {
+	o := newOptions(opts...)
+	return func(r Resources) error {
+		return o.patch(content(r.ObservedComposed), content(r.DesiredComposite), from, to)
+	}
+}
+
+// FromEnvironmentFieldPath returns a Patch that copies the value of the
+// supplied field path of the environment to the supplied field path of the
+// desired composed resource. The field path is copied to the same field path
+// if to is empty.
+func FromEnvironmentFieldPath(from, to string, opts ...Option) Patch {
+	o := newOptions(opts...)
+	return func(r Resources) error {
+		return o.patch(content(r.Environment), content(r.DesiredComposed), from, to)
+	}
+}
+
+// CombineFromComposite returns a Patch that combines the values of the
+// supplied field paths of the observed composite resource into a string using
+// the supplied fmt.Sprintf format, and writes it to the supplied field path of
+// the desired composed resource. Like the other patches, a PolicyOptional
+// patch is skipped if any of the field paths it reads from don't exist.
+func CombineFromComposite(from []string, format, to string, opts ...Option) Patch {
+	o := newOptions(opts...)
+	return func(r Resources) error {
+		if len(from) == 0 {
+			return errors.New("combine patch must read from at least one field path")
+		}
+		if to == "" {
+			return errors.New("combine patch must write to a field path")
+		}
+		src := fieldpath.Pave(content(r.ObservedComposite))
+		vars := make([]any, len(from))
+		for i, path := range from {
+			v, err := src.GetValue(path)
+			if fieldpath.IsNotFound(err) && o.policy != PolicyRequired {
+				return nil
+			}
+			if err != nil {
+				return errors.Wrapf(err, "cannot get field path %q", path)
+			}
+			vars[i] = v
+		}
+		return o.write(content(r.DesiredComposed), to, fmt.Sprintf(format, vars...))
+	}
+}
+
+// patch copies the value of the from field path of the src object to the to
+// field path of the dst object.
+func (o *options) patch(src, dst map[string]any, from, to string) error {
+	if from == "" {
+		return errors.New("patch must read from a field path")
+	}
+	if to == "" {
+		to = from
+	}
+	v, err := fieldpath.Pave(src).GetValue(from)
+	if fieldpath.IsNotFound(err) && o.policy != PolicyRequired {
+		return nil
+	}
+	if err != nil {
+		return errors.Wrapf(err, "cannot get field path %q", from)
+	}
+	return o.write(dst, to, v)
+}
+
+// write transforms the supplied value then writes it to the supplied field
+// path of the dst object.
+func (o *options) write(dst map[string]any, to string, v any) error {
+	if dst == nil {
+		return errors.Errorf("cannot set field path %q: resource to patch doesn't exist", to)
+	}
+	for i, t := range o.transforms {
+		var err error
+		if v, err = t(v); err != nil {
+			return errors.Wrapf(err, "cannot apply transform %d", i)
+		}
+	}
+	return errors.Wrapf(fieldpath.Pave(dst).SetValue(to, v), "cannot set field path %q", to)
+}
+
+// content returns the supplied object's content, or nil if it's nil.
+func content(o interface{ UnstructuredContent() map[string]any }) map[string]any {
+	if o == nil || reflect.ValueOf(o).IsNil() {
+		return nil
+	}
+	return o.UnstructuredContent()
+}
+
+// Apply the supplied patches, in order.
+func Apply(r Resources, ps ...Patch) error {
+	for i, p := range ps {
+		if err := p(r); err != nil {
+			return errors.Wrapf(err, "cannot apply patch %d", i)
+		}
+	}
+	return nil
+}
+
+// ApplyComposed applies the supplied patches of each desired composed
+// resource. Patches read from the environment and the observed resources in
+// the supplied request, and write to the supplied desired composite resource
+// and desired composed resources.
+func ApplyComposed(req *v1.RunFunctionRequest, dxr *resource.Composite, dcds map[resource.Name]*resource.DesiredComposed, patches Patches) error {
+	if dxr == nil {
+		return errors.New("cannot patch composed resources: desired composite resource is nil")
+	}
+	env, err := GetEnvironment(req)
+	if err != nil {
+		return err
+	}
+	oxr, err := request.GetObservedCompositeResource(req)
+	if err != nil {
+		return errors.Wrap(err, "cannot get observed composite resource")
+	}
+	ocds, err := request.GetObservedComposedResources(req)
+	if err != nil {
+		return errors.Wrap(err, "cannot get observed composed resources")
+	}
+
+	names := make([]resource.Name, 0, len(patches))
+	for name := range patches {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+
+	for _, name := range names {
+		dcd, ok := dcds[name]
+		if !ok || dcd == nil {
+			return errors.Errorf("cannot patch composed resource %q: no desired composed resource with that name", name)
+		}
+		r := Resources{
+			Environment:       env,
+			ObservedComposite: oxr.Resource,
+			DesiredComposite:  dxr.Resource,
+			DesiredComposed:   dcd.Resource,
+		}
+		if ocd, ok := ocds[name]; ok {
+			r.ObservedComposed = ocd.Resource
+		}
+		if err := Apply(r, patches[name]...); err != nil {
+			return errors.Wrapf(err, "cannot patch composed resource %q", name)
+		}
+	}
+	return nil
+}
+
+// GetEnvironment gets the environment from the supplied request's context. It
+// returns an empty environment if the context doesn't contain one.
+func GetEnvironment(req *v1.RunFunctionRequest) (*unstructured.Unstructured, error) {
+	env := &unstructured.Unstructured{Object: map[string]any{}}
+	v, ok := request.GetContextKey(req, fncontext.KeyEnvironment)
+	if !ok {
+		return env, nil
+	}
+	s := v.GetStructValue()
+	if s == nil {
+		return nil, errors.Errorf("cannot get environment: context key %q is not an object", fncontext.KeyEnvironment)
+	}
+	env.Object = s.AsMap()
+	return env, nil
+}
@@ -0,0 +1,288 @@
+/*
+Copyright 2026 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package patch
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"google.golang.org/protobuf/types/known/structpb"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	fncontext "github.com/crossplane/function-sdk-go/context"
+	v1 "github.com/crossplane/function-sdk-go/proto/v1"
+	"github.com/crossplane/function-sdk-go/resource"
+	"github.com/crossplane/function-sdk-go/resource/composed"
+	"github.com/crossplane/function-sdk-go/resource/composite"
+)
+
+func MustContent(t *testing.T, j string) map[string]any {
+	t.Helper()
+	return resource.MustStructJSON(j).AsMap()
+}
+
+// MustJSON returns the supplied content as JSON, so that content can be
+// compared regardless of whether its numbers are int64 or float64.
+func MustJSON(t *testing.T, content map[string]any) string {
+	t.Helper()
+	b, err := json.Marshal(content)
+	if err != nil {
+		t.Fatalf("json.Marshal(...): %v", err)
+	}
+	return string(b)
+}
+
+func TestApply(t *testing.T) {
+	type want struct {
+		dxr string
+		dcd string
+		err error
+	}
+
+	cases := map[string]struct {
+		reason string
+		ocd    string
+		ps     []Patch
+		want   want
+	}{
+		"FromCompositeFieldPath": {
+			reason: "We should copy a field of the observed XR to the desired composed resource, transforming it.",
+			ps: []Patch{
+				FromCompositeFieldPath("spec.region", "spec.forProvider.region", WithTransforms(Map(map[string]any{"us-west": "us-west-1"}))),
+				FromCompositeFieldPath("spec.size", "", WithTransforms(Multiply(2))),
+			},
+			want: want{
+				dxr: `{}`,
+				dcd: `{"spec": {"size": 4, "forProvider": {"region": "us-west-1"}}}`,
+			},
+		},
+		"FromCompositeFieldPathOptional": {
+			reason: "We should skip an optional patch if the field path it reads from doesn't exist.",
+			ps:     []Patch{FromCompositeFieldPath("spec.missing", "spec.forProvider.missing")},
+			want: want{
+				dxr: `{}`,
+				dcd: `{"spec": {}}`,
+			},
+		},
+		"FromCompositeFieldPathRequired": {
+			reason: "We should return an error if the field path a required patch reads from doesn't exist.",
+			ps:     []Patch{FromCompositeFieldPath("spec.missing", "spec.forProvider.missing", WithPolicy(PolicyRequired))},
+			want: want{
+				dxr: `{}`,
+				dcd: `{"spec": {}}`,
+				err: cmpopts.AnyError,
+			},
+		},
+		"FromCompositeFieldPathTransformError": {
+			reason: "We should return an error if a transform returns an error.",
+			ps:     []Patch{FromCompositeFieldPath("spec.region", "spec.forProvider.region", WithTransforms(Map(map[string]any{})))},
+			want: want{
+				dxr: `{}`,
+				dcd: `{"spec": {}}`,
+				err: cmpopts.AnyError,
+			},
+		},
+		"ToCompositeFieldPath": {
+			reason: "We should copy a field of the observed composed resource to the desired XR.",
+			ocd:    `{"status": {"atProvider": {"arn": "arn:aws:s3:::cool"}}}`,
+			ps:     []Patch{ToCompositeFieldPath("status.atProvider.arn", "status.arn")},
+			want: want{
+				dxr: `{"status": {"arn": "arn:aws:s3:::cool"}}`,
+				dcd: `{"spec": {}}`,
+			},
+		},
+		"ToCompositeFieldPathNotObserved": {
+			reason: "We should skip an optional patch from a composed resource that hasn't been observed yet.",
+			ps:     []Patch{ToCompositeFieldPath("status.atProvider.arn", "status.arn")},
+			want: want{
+				dxr: `{}`,
+				dcd: `{"spec": {}}`,
+			},
+		},
+		"FromEnvironmentFieldPath": {
+			reason: "We should copy a field of the environment to the desired composed resource.",
+			ps:     []Patch{FromEnvironmentFieldPath("vpcId", "spec.forProvider.vpcId")},
+			want: want{
+				dxr: `{}`,
+				dcd: `{"spec": {"forProvider": {"vpcId": "vpc-123"}}}`,
+			},
+		},
+		"CombineFromComposite": {
+			reason: "We should combine fields of the observed XR into a string.",
+			ps:     []Patch{CombineFromComposite([]string{"spec.region", "spec.size"}, "%s-%v", "metadata.annotations[example.org/id]")},
+			want: want{
+				dxr: `{}`,
+				dcd: `{"metadata": {"annotations": {"example.org/id": "us-west-2"}}, "spec": {}}`,
+			},
+		},
+		"CombineFromCompositeOptional": {
+			reason: "We should skip an optional combine patch if any of the field paths it reads from don't exist.",
+			ps:     []Patch{CombineFromComposite([]string{"spec.region", "spec.missing"}, "%s-%s", "spec.id")},
+			want: want{
+				dxr: `{}`,
+				dcd: `{"spec": {}}`,
+			},
+		},
+		"CombineFromCompositeRequired": {
+			reason: "We should return an error if any of the field paths a required combine patch reads from don't exist.",
+			ps:     []Patch{CombineFromComposite([]string{"spec.region", "spec.missing"}, "%s-%s", "spec.id", WithPolicy(PolicyRequired))},
+			want: want{
+				dxr: `{}`,
+				dcd: `{"spec": {}}`,
+				err: cmpopts.AnyError,
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			r := Resources{
+				Environment:       &unstructured.Unstructured{Object: MustContent(t, `{"vpcId": "vpc-123"}`)},
+				ObservedComposite: &composite.Unstructured{Unstructured: unstructured.Unstructured{Object: MustContent(t, `{"spec": {"region": "us-west", "size": 2}}`)}},
+				DesiredComposite:  composite.New(),
+				DesiredComposed:   &composed.Unstructured{Unstructured: unstructured.Unstructured{Object: MustContent(t, `{"spec": {}}`)}},
+			}
+			if tc.ocd != "" {
+				r.ObservedComposed = &composed.Unstructured{Unstructured: unstructured.Unstructured{Object: MustContent(t, tc.ocd)}}
+			}
+
+			err := Apply(r, tc.ps...)
+
+			if diff := cmp.Diff(MustJSON(t, MustContent(t, tc.want.dxr)), MustJSON(t, r.DesiredComposite.Object)); diff != "" {
+				t.Errorf("\n%s\nApply(...): -want desired XR, +got desired XR:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(MustJSON(t, MustContent(t, tc.want.dcd)), MustJSON(t, r.DesiredComposed.Object)); diff != "" {
+				t.Errorf("\n%s\nApply(...): -want desired composed, +got desired composed:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.err, err, cmpopts.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nApply(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestApplyComposed(t *testing.T) {
+	req := &v1.RunFunctionRequest{
+		Observed: &v1.State{
+			Composite: &v1.Resource{Resource: resource.MustStructJSON(`{"spec": {"region": "us-west-2"}}`)},
+			Resources: map[string]*v1.Resource{
+				"bucket": {Resource: resource.MustStructJSON(`{"status": {"atProvider": {"arn": "arn:aws:s3:::cool"}}}`)},
+			},
+		},
+		Context: &structpb.Struct{Fields: map[string]*structpb.Value{
+			fncontext.KeyEnvironment: structpb.NewStructValue(resource.MustStructJSON(`{"tier": "gold"}`)),
+		}},
+	}
+
+	type want struct {
+		dxr  string
+		dcds map[resource.Name]string
+		err  error
+	}
+
+	cases := map[string]struct {
+		reason  string
+		noDXR   bool
+		dcds    []resource.Name
+		patches Patches
+		want    want
+	}{
+		"Patched": {
+			reason: "We should patch each desired composed resource, and the desired XR.",
+			dcds:   []resource.Name{"bucket", "policy"},
+			patches: Patches{
+				"bucket": {
+					FromCompositeFieldPath("spec.region", "spec.forProvider.region"),
+					FromEnvironmentFieldPath("tier", "metadata.labels[example.org/tier]"),
+					ToCompositeFieldPath("status.atProvider.arn", "status.bucketArn"),
+				},
+				"policy": {
+					FromCompositeFieldPath("spec.region", "spec.forProvider.region"),
+					ToCompositeFieldPath("status.atProvider.arn", "status.policyArn"),
+				},
+			},
+			want: want{
+				dxr: `{"status": {"bucketArn": "arn:aws:s3:::cool"}}`,
+				dcds: map[resource.Name]string{
+					"bucket": `{"metadata": {"labels": {"example.org/tier": "gold"}}, "spec": {"forProvider": {"region": "us-west-2"}}}`,
+					"policy": `{"spec": {"forProvider": {"region": "us-west-2"}}}`,
+				},
+			},
+		},
+		"NoDesiredComposite": {
+			reason: "We should return an error if there's no desired composite resource.",
+			noDXR:  true,
+			dcds:   []resource.Name{"bucket"},
+			patches: Patches{
+				"bucket": {FromCompositeFieldPath("spec.region", "spec.forProvider.region")},
+			},
+			want: want{
+				dcds: map[resource.Name]string{"bucket": `{}`},
+				err:  cmpopts.AnyError,
+			},
+		},
+		"NoDesiredComposed": {
+			reason: "We should return an error if there's no desired composed resource to patch.",
+			dcds:   []resource.Name{},
+			patches: Patches{
+				"bucket": {FromCompositeFieldPath("spec.region", "spec.forProvider.region")},
+			},
+			want: want{
+				dxr:  `{}`,
+				dcds: map[resource.Name]string{},
+				err:  cmpopts.AnyError,
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			dxr := &resource.Composite{Resource: composite.New()}
+			if tc.noDXR {
+				dxr = nil
+			}
+			dcds := map[resource.Name]*resource.DesiredComposed{}
+			for _, name := range tc.dcds {
+				dcds[name] = resource.NewDesiredComposed()
+			}
+
+			err := ApplyComposed(req, dxr, dcds, tc.patches)
+
+			if dxr != nil {
+				if diff := cmp.Diff(MustJSON(t, MustContent(t, tc.want.dxr)), MustJSON(t, dxr.Resource.Object)); diff != "" {
+					t.Errorf("\n%s\nApplyComposed(...): -want desired XR, +got desired XR:\n%s", tc.reason, diff)
+				}
+			}
+			got := map[resource.Name]string{}
+			for name, dcd := range dcds {
+				got[name] = MustJSON(t, dcd.Resource.Object)
+			}
+			want := map[resource.Name]string{}
+			for name, j := range tc.want.dcds {
+				want[name] = MustJSON(t, MustContent(t, j))
+			}
+			if diff := cmp.Diff(want, got); diff != "" {
+				t.Errorf("\n%s\nApplyComposed(...): -want desired composed, +got desired composed:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.err, err, cmpopts.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nApplyComposed(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,410 @@
+/*
+Copyright 2026 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package patch
+
+import (
+	"crypto/sha1" //nolint:gosec // Not used for security. Supported for parity with patch-and-transform.
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"hash/adler32"
+	"regexp"
+	"strconv"
+	"strings"
+
+	kresource "k8s.io/apimachinery/pkg/api/resource"
+
+	"github.com/crossplane/function-sdk-go/errors"
+)
+
+// A Transform transforms the supplied value. Values are JSON compatible; for
+// example numbers are float64 or int64.
+type Transform func(in any) (any, error)
+
+// Map returns a Transform that uses the supplied string input as a key of the
+// supplied map, and returns its value. It returns an error if the key isn't in
+// the map.
+func Map(m map[string]any) Transform {
+	return func(in any) (any, error) {
+		s, ok := in.(string)
+		if !ok {
+			return nil, errors.Errorf("map transform input must be a string, not %T", in)
+		}
+		v, ok := m[s]
+		if !ok {
+			return nil, errors.Errorf("key %q not found in map", s)
+		}
+		return v, nil
+	}
+}
+
+// A MatchPattern is a pattern used by a match transform.
+type MatchPattern struct {
+	literal *string
+	regexp  *regexp.Regexp
+	result  any
+}
+
+// MatchLiteral returns a MatchPattern that matches input that is exactly the
+// supplied string, and returns the supplied result.
+func MatchLiteral(literal string, result any) MatchPattern {
+	return MatchPattern{literal: &literal, result: result}
+}
+
+// MatchRegexp returns a MatchPattern that matches string input that matches the
+// supplied regular expression, and returns the supplied result. Like
+// regexp.MustCompile, it panics if the expression can't be compiled. Use
+// regexp.Compile to check expressions that aren't known to be valid, for
+// example those read from a Function's input.
+func MatchRegexp(expr string, result any) MatchPattern {
+	return MatchPattern{regexp: regexp.MustCompile(expr), result: result}
+}
+
+// Match returns a Transform that returns the result of the first of the
+// supplied patterns that matches the input. It returns the supplied fallback
+// value if no pattern matches.
+func Match(fallback any, patterns ...MatchPattern) Transform {
+	return match(func(_ any) any { return fallback }, patterns)
+}
+
+// MatchOrInput returns a Transform that returns the result of the first of the
+// supplied patterns that matches the input. It returns the input if no pattern
+// matches.
+func MatchOrInput(patterns ...MatchPattern) Transform {
+	return match(func(in any) any { return in }, patterns)
+}
+
+func match(fallback func(in any) any, patterns []MatchPattern) Transform {
+	return func(in any) (any, error) {
+		for i, p := range patterns {
+			switch {
+			case p.literal != nil:
+				if s, ok := in.(string); ok && s == *p.literal {
+					return p.result, nil
+				}
+			case p.regexp != nil:
+				s, ok := in.(string)
+				if !ok {
+					return nil, errors.Errorf("match pattern %d: regexp input must be a string, not %T", i, in)
+				}
+				if p.regexp.MatchString(s) {
+					return p.result, nil
+				}
+			}
+		}
+		return fallback(in), nil
+	}
+}
+
+// Multiply returns a Transform that multiplies numeric input by the supplied
+// value. The output is the same type as the input.
+func Multiply(by int64) Transform {
+	return mathTransform(func(i int64) int64 { return i * by }, func(f float64) float64 { return f * float64(by) })
+}
+
+// ClampMin returns a Transform that returns the supplied minimum value if
+// numeric input is less than it. The output is the same type as the input.
+func ClampMin(minimum int64) Transform {
+	return mathTransform(
+		func(i int64) int64 { return max(i, minimum) },
+		func(f float64) float64 { return max(f, float64(minimum)) },
+	)
+}
+
+// ClampMax returns a Transform that returns the supplied maximum value if
+// numeric input is greater than it. The output is the same type as the input.
+func ClampMax(maximum int64) Transform {
+	return mathTransform(
+		func(i int64) int64 { return min(i, maximum) },
+		func(f float64) float64 { return min(f, float64(maximum)) },
+	)
+}
+
+func mathTransform(i func(int64) int64, f func(float64) float64) Transform {
+	return func(in any) (any, error) {
+		switch v := in.(type) {
+		case int:
+			return i(int64(v)), nil
+		case int64:
+			return i(v), nil
+		case float64:
+			return f(v), nil
+		default:
+			return nil, errors.Errorf("math transform input must be a number, not %T", in)
+		}
+	}
+}
+
+// StringFormat returns a Transform that formats the input using the supplied
+// fmt.Sprintf format.
+func StringFormat(format string) Transform {
+	return func(in any) (any, error) {
+		return fmt.Sprintf(format, in), nil
+	}
+}
+
+// A StringConversion converts a string.
+type StringConversion string
+
+// String conversions.
+const (
+	StringConversionToUpper    StringConversion = "ToUpper"
+	StringConversionToLower    StringConversion = "ToLower"
+	StringConversionToBase64   StringConversion = "ToBase64"
+	StringConversionFromBase64 StringConversion = "FromBase64"
+	StringConversionToJSON     StringConversion = "ToJson"
+	StringConversionToSHA1     StringConversion = "ToSha1"
+	StringConversionToSHA256   StringConversion = "ToSha256"
+	StringConversionToSHA512   StringConversion = "ToSha512"
+	StringConversionToAdler32  StringConversion = "ToAdler32"
+)
+
+// StringConvert returns a Transform that applies the supplied conversion to
+// the input. The hash conversions hash string input as is, and other input
+// encoded as JSON.
+func StringConvert(c StringConversion) Transform {
+	return func(in any) (any, error) {
+		switch c {
+		case StringConversionToUpper:
+			return strings.ToUpper(fmt.Sprint(in)), nil
+		case StringConversionToLower:
+			return strings.ToLower(fmt.Sprint(in)), nil
+		case StringConversionToBase64:
+			return base64.StdEncoding.EncodeToString([]byte(fmt.Sprint(in))), nil
+		case StringConversionFromBase64:
+			b, err := base64.StdEncoding.DecodeString(fmt.Sprint(in))
+			return string(b), errors.Wrap(err, "cannot decode base64")
+		case StringConversionToJSON:
+			b, err := json.Marshal(in)
+			return string(b), errors.Wrap(err, "cannot encode JSON")
+		case StringConversionToSHA1:
+			return hash(in, func(b []byte) []byte { h := sha1.Sum(b); return h[:] }) //nolint:gosec // See import.
+		case StringConversionToSHA256:
+			return hash(in, func(b []byte) []byte { h := sha256.Sum256(b); return h[:] })
+		case StringConversionToSHA512:
+			return hash(in, func(b []byte) []byte { h := sha512.Sum512(b); return h[:] })
+		case StringConversionToAdler32:
+			b, err := hashInput(in)
+			if err != nil {
+				return nil, err
+			}
+			return strconv.FormatUint(uint64(adler32.Checksum(b)), 10), nil
+		default:
+			return nil, errors.Errorf("unknown string conversion %q", c)
+		}
+	}
+}
+
+func hash(in any, sum func([]byte) []byte) (any, error) {
+	b, err := hashInput(in)
+	if err != nil {
+		return nil, err
+	}
+	return hex.EncodeToString(sum(b)), nil
+}
+
+func hashInput(in any) ([]byte, error) {
+	if s, ok := in.(string); ok {
+		return []byte(s), nil
+	}
+	b, err := json.Marshal(in)
+	return b, errors.Wrap(err, "cannot encode JSON")
+}
+
+// TrimPrefix returns a Transform that removes the supplied prefix from the
+// input.
+func TrimPrefix(prefix string) Transform {
+	return func(in any) (any, error) {
+		return strings.TrimPrefix(fmt.Sprint(in), prefix), nil
+	}
+}
+
+// TrimSuffix returns a Transform that removes the supplied suffix from the
+// input.
+func TrimSuffix(suffix string) Transform {
+	return func(in any) (any, error) {
+		return strings.TrimSuffix(fmt.Sprint(in), suffix), nil
+	}
+}
+
+// StringRegexp returns a Transform that returns the supplied capture group of
+// the first match of the supplied regular expression in the input. Group 0 is
+// the entire match. The Transform returns an error if the expression doesn't
+// match. Like regexp.MustCompile, StringRegexp panics if the expression can't
+// be compiled.
+func StringRegexp(expr string, group int) Transform {
+	re := regexp.MustCompile(expr)
+	return func(in any) (any, error) {
+		groups := re.FindStringSubmatch(fmt.Sprint(in))
+		if groups == nil {
+			return nil, errors.Errorf("regexp %q had no matches", expr)
+		}
+		if group < 0 || group >= len(groups) {
+			return nil, errors.Errorf("regexp %q has no capture group %d", expr, group)
+		}
+		return groups[group], nil
+	}
+}
+
+// A ConvertType is a type a convert transform converts to.
+type ConvertType string
+
+// Convert types.
+const (
+	ConvertTypeString  ConvertType = "string"
+	ConvertTypeBool    ConvertType = "bool"
+	ConvertTypeInt64   ConvertType = "int64"
+	ConvertTypeFloat64 ConvertType = "float64"
+	ConvertTypeObject  ConvertType = "object"
+	ConvertTypeArray   ConvertType = "array"
+)
+
+// A ConvertFormat is the format of the input of a convert transform.
+type ConvertFormat string
+
+// Convert formats.
+const (
+	// ConvertFormatNone input has no special format.
+	ConvertFormatNone ConvertFormat = ""
+
+	// ConvertFormatQuantity input is a string Kubernetes quantity, like
+	// "250m" or "1Gi". It can be converted to int64 or float64.
+	ConvertFormatQuantity ConvertFormat = "quantity"
+
+	// ConvertFormatJSON input is a string of JSON. It can be converted to an
+	// object or an array.
+	ConvertFormatJSON ConvertFormat = "json"
+)
+
+// Convert returns a Transform that converts the input to the supplied type.
+// Input must be a string, bool, int64, or float64, and be in the supplied
+// format.
+func Convert(to ConvertType, format ConvertFormat) Transform {
+	return func(in any) (any, error) {
+		if i, ok := in.(int); ok {
+			in = int64(i)
+		}
+		switch format {
+		case ConvertFormatNone:
+			return convert(in, to)
+		case ConvertFormatQuantity:
+			return convertQuantity(in, to)
+		case ConvertFormatJSON:
+			return convertJSON(in, to)
+		default:
+			return nil, errors.Errorf("unknown convert format %q", format)
+		}
+	}
+}
+
+func convert(in any, to ConvertType) (any, error) { //nolint:gocyclo // Only a long switch.
+	switch to {
+	case ConvertTypeString:
+		switch v := in.(type) {
+		case string:
+			return v, nil
+		case bool:
+			return strconv.FormatBool(v), nil
+		case int64:
+			return strconv.FormatInt(v, 10), nil
+		case float64:
+			return strconv.FormatFloat(v, 'f', -1, 64), nil
+		}
+	case ConvertTypeBool:
+		switch v := in.(type) {
+		case string:
+			b, err := strconv.ParseBool(v)
+			return b, errors.Wrapf(err, "cannot convert %q to bool", v)
+		case bool:
+			return v, nil
+		}
+	case ConvertTypeInt64:
+		switch v := in.(type) {
+		case string:
+			i, err := strconv.ParseInt(v, 10, 64)
+			return i, errors.Wrapf(err, "cannot convert %q to int64", v)
+		case bool:
+			if v {
+				return int64(1), nil
+			}
+			return int64(0), nil
+		case int64:
+			return v, nil
+		case float64:
+			return int64(v), nil
+		}
+	case ConvertTypeFloat64:
+		switch v := in.(type) {
+		case string:
+			f, err := strconv.ParseFloat(v, 64)
+			return f, errors.Wrapf(err, "cannot convert %q to float64", v)
+		case bool:
+			if v {
+				return float64(1), nil
+			}
+			return float64(0), nil
+		case int64:
+			return float64(v), nil
+		case float64:
+			return v, nil
+		}
+	case ConvertTypeObject, ConvertTypeArray:
+		return nil, errors.Errorf("cannot convert to %s without format %q", to, ConvertFormatJSON)
+	default:
+		return nil, errors.Errorf("unknown convert type %q", to)
+	}
+	return nil, errors.Errorf("cannot convert %T to %s", in, to)
+}
+
+func convertQuantity(in any, to ConvertType) (any, error) {
+	s, ok := in.(string)
+	if !ok {
+		return nil, errors.Errorf("quantity input must be a string, not %T", in)
+	}
+	q, err := kresource.ParseQuantity(s)
+	if err != nil {
+		return nil, errors.Wrapf(err, "cannot parse quantity %q", s)
+	}
+	switch to {
+	case ConvertTypeInt64:
+		return q.Value(), nil
+	case ConvertTypeFloat64:
+		return q.AsApproximateFloat64(), nil
+	default:
+		return nil, errors.Errorf("cannot convert quantity to %s", to)
+	}
+}
+
+func convertJSON(in any, to ConvertType) (any, error) {
+	s, ok := in.(string)
+	if !ok {
+		return nil, errors.Errorf("JSON input must be a string, not %T", in)
+	}
+	switch to {
+	case ConvertTypeObject:
+		out := map[string]any{}
+		return out, errors.Wrap(json.Unmarshal([]byte(s), &out), "cannot convert JSON to object")
+	case ConvertTypeArray:
+		out := []any{}
+		return out, errors.Wrap(json.Unmarshal([]byte(s), &out), "cannot convert JSON to array")
+	default:
+		return nil, errors.Errorf("cannot convert JSON to %s", to)
+	}
+}
@@ -0,0 +1,287 @@
+/*
+Copyright 2026 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package patch
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+)
+
+func TestTransforms(t *testing.T) {
+	type want struct {
+		out any
+		err error
+	}
+
+	cases := map[string]struct {
+		reason string
+		t      Transform
+		in     any
+		want   want
+	}{
+		"Map": {
+			reason: "A map transform should return the value of the input key.",
+			t:      Map(map[string]any{"us-west": "us-west-1"}),
+			in:     "us-west",
+			want:   want{out: "us-west-1"},
+		},
+		"MapKeyNotFound": {
+			reason: "A map transform should return an error if the input key isn't in the map.",
+			t:      Map(map[string]any{"us-west": "us-west-1"}),
+			in:     "eu-west",
+			want:   want{err: cmpopts.AnyError},
+		},
+		"MapNotString": {
+			reason: "A map transform should return an error if the input isn't a string.",
+			t:      Map(map[string]any{"1": "one"}),
+			in:     float64(1),
+			want:   want{err: cmpopts.AnyError},
+		},
+		"MatchLiteral": {
+			reason: "A match transform should return the result of the first matching pattern.",
+			t:      Match("default", MatchRegexp("^us-", "us"), MatchLiteral("eu-west-1", "eu")),
+			in:     "eu-west-1",
+			want:   want{out: "eu"},
+		},
+		"MatchRegexp": {
+			reason: "A match transform should match input against regular expressions.",
+			t:      Match("default", MatchRegexp("^us-", "us"), MatchLiteral("eu-west-1", "eu")),
+			in:     "us-east-1",
+			want:   want{out: "us"},
+		},
+		"MatchFallbackValue": {
+			reason: "A match transform should return its fallback value if no pattern matches.",
+			t:      Match("default", MatchLiteral("eu-west-1", "eu")),
+			in:     "ap-south-1",
+			want:   want{out: "default"},
+		},
+		"MatchFallbackToInput": {
+			reason: "A match transform should return its input if no pattern matches and it's configured to.",
+			t:      MatchOrInput(MatchLiteral("eu-west-1", "eu")),
+			in:     "ap-south-1",
+			want:   want{out: "ap-south-1"},
+		},
+		"MatchRegexpNotString": {
+			reason: "A match transform should return an error if input matched against a regular expression isn't a string.",
+			t:      Match(nil, MatchRegexp("^us-", "us")),
+			in:     float64(1),
+			want:   want{err: cmpopts.AnyError},
+		},
+		"MultiplyFloat": {
+			reason: "A multiply transform should multiply float input, returning a float.",
+			t:      Multiply(2),
+			in:     float64(1.5),
+			want:   want{out: float64(3)},
+		},
+		"MultiplyInteger": {
+			reason: "A multiply transform should multiply integer input, returning an integer.",
+			t:      Multiply(2),
+			in:     int64(3),
+			want:   want{out: int64(6)},
+		},
+		"MultiplyNotNumber": {
+			reason: "A multiply transform should return an error if the input isn't a number.",
+			t:      Multiply(2),
+			in:     "3",
+			want:   want{err: cmpopts.AnyError},
+		},
+		"ClampMin": {
+			reason: "A clamp min transform should return the minimum if the input is less than it.",
+			t:      ClampMin(10),
+			in:     float64(5),
+			want:   want{out: float64(10)},
+		},
+		"ClampMax": {
+			reason: "A clamp max transform should return the maximum if the input is greater than it.",
+			t:      ClampMax(10),
+			in:     int64(50),
+			want:   want{out: int64(10)},
+		},
+		"StringFormat": {
+			reason: "A string format transform should format the input.",
+			t:      StringFormat("db-%s"),
+			in:     "cool",
+			want:   want{out: "db-cool"},
+		},
+		"StringConvertToUpper": {
+			reason: "A string convert transform should convert the input to upper case.",
+			t:      StringConvert(StringConversionToUpper),
+			in:     "cool",
+			want:   want{out: "COOL"},
+		},
+		"StringConvertFromBase64": {
+			reason: "A string convert transform should decode base64 input.",
+			t:      StringConvert(StringConversionFromBase64),
+			in:     "Y29vbA==",
+			want:   want{out: "cool"},
+		},
+		"StringConvertToJSON": {
+			reason: "A string convert transform should encode the input as JSON.",
+			t:      StringConvert(StringConversionToJSON),
+			in:     map[string]any{"a": "b"},
+			want:   want{out: `{"a":"b"}`},
+		},
+		"StringConvertToSHA256": {
+			reason: "A string convert transform should hash the input.",
+			t:      StringConvert(StringConversionToSHA256),
+			in:     "cool",
+			want:   want{out: "c34045c1a1db8d1b3fca8a692198466952daae07eaf6104b4c87ed3b55b6af1b"},
+		},
+		"StringConvertToAdler32": {
+			reason: "A string convert transform should checksum the input.",
+			t:      StringConvert(StringConversionToAdler32),
+			in:     "cool",
+			want:   want{out: "69665198"},
+		},
+		"StringConvertUnknown": {
+			reason: "A string convert transform should return an error if the conversion is unknown.",
+			t:      StringConvert("ToKlingon"),
+			in:     "cool",
+			want:   want{err: cmpopts.AnyError},
+		},
+		"TrimPrefix": {
+			reason: "A trim prefix transform should remove the prefix from the input.",
+			t:      TrimPrefix("arn:aws:"),
+			in:     "arn:aws:s3",
+			want:   want{out: "s3"},
+		},
+		"TrimSuffix": {
+			reason: "A trim suffix transform should remove the suffix from the input.",
+			t:      TrimSuffix("-bucket"),
+			in:     "cool-bucket",
+			want:   want{out: "cool"},
+		},
+		"StringRegexp": {
+			reason: "A string regexp transform should return the requested capture group.",
+			t:      StringRegexp(`^arn:aws:([a-z0-9]+):`, 1),
+			in:     "arn:aws:s3:::cool",
+			want:   want{out: "s3"},
+		},
+		"StringRegexpNoMatch": {
+			reason: "A string regexp transform should return an error if the expression doesn't match.",
+			t:      StringRegexp(`^arn:aws:([a-z0-9]+):`, 1),
+			in:     "cool",
+			want:   want{err: cmpopts.AnyError},
+		},
+		"StringRegexpNoGroup": {
+			reason: "A string regexp transform should return an error if the capture group doesn't exist.",
+			t:      StringRegexp(`^arn:aws:([a-z0-9]+):`, 2),
+			in:     "arn:aws:s3:::cool",
+			want:   want{err: cmpopts.AnyError},
+		},
+		"ConvertFloatToInt64": {
+			reason: "A convert transform should truncate floats when converting to int64.",
+			t:      Convert(ConvertTypeInt64, ConvertFormatNone),
+			in:     float64(3.7),
+			want:   want{out: int64(3)},
+		},
+		"ConvertStringToBool": {
+			reason: "A convert transform should parse strings when converting to bool.",
+			t:      Convert(ConvertTypeBool, ConvertFormatNone),
+			in:     "true",
+			want:   want{out: true},
+		},
+		"ConvertFloatToString": {
+			reason: "A convert transform should format floats without trailing zeros when converting to string.",
+			t:      Convert(ConvertTypeString, ConvertFormatNone),
+			in:     float64(3),
+			want:   want{out: "3"},
+		},
+		"ConvertInvalidString": {
+			reason: "A convert transform should return an error if a string can't be parsed.",
+			t:      Convert(ConvertTypeFloat64, ConvertFormatNone),
+			in:     "three",
+			want:   want{err: cmpopts.AnyError},
+		},
+		"ConvertUnsupported": {
+			reason: "A convert transform should return an error if the input can't be converted to the type.",
+			t:      Convert(ConvertTypeBool, ConvertFormatNone),
+			in:     float64(1),
+			want:   want{err: cmpopts.AnyError},
+		},
+		"ConvertQuantityToInt64": {
+			reason: "A convert transform should parse quantities.",
+			t:      Convert(ConvertTypeInt64, ConvertFormatQuantity),
+			in:     "1Gi",
+			want:   want{out: int64(1073741824)},
+		},
+		"ConvertQuantityToFloat64": {
+			reason: "A convert transform should parse fractional quantities.",
+			t:      Convert(ConvertTypeFloat64, ConvertFormatQuantity),
+			in:     "250m",
+			want:   want{out: float64(0.25)},
+		},
+		"ConvertJSONToObject": {
+			reason: "A convert transform should parse JSON objects.",
+			t:      Convert(ConvertTypeObject, ConvertFormatJSON),
+			in:     `{"a": ["b"]}`,
+			want:   want{out: map[string]any{"a": []any{"b"}}},
+		},
+		"ConvertJSONToWrongType": {
+			reason: "A convert transform should return an error if JSON isn't the requested type.",
+			t:      Convert(ConvertTypeArray, ConvertFormatJSON),
+			in:     `{"a": ["b"]}`,
+			want:   want{err: cmpopts.AnyError},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			out, err := tc.t(tc.in)
+
+			// Output is undefined when a transform returns an error.
+			if err != nil {
+				out = nil
+			}
+			if diff := cmp.Diff(tc.want.out, out); diff != "" {
+				t.Errorf("\n%s\nTransform(...): -want, +got:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.err, err, cmpopts.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nTransform(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestInvalidRegexp(t *testing.T) {
+	cases := map[string]struct {
+		reason string
+		fn     func()
+	}{
+		"MatchRegexp": {
+			reason: "MatchRegexp should panic if its regular expression is invalid.",
+			fn:     func() { MatchRegexp("[", "oops") },
+		},
+		"StringRegexp": {
+			reason: "StringRegexp should panic if its regular expression is invalid.",
+			fn:     func() { StringRegexp("[", 0) },
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("\n%s\nexpected a panic", tc.reason)
+				}
+			}()
+			tc.fn()
+		})
+	}
+}
@@ -0,0 +1,233 @@
+/*
+Copyright 2026 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package redact removes secrets from RunFunctionRequests and
+// RunFunctionResponses so they can be safely logged.
+package redact
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/structpb"
+
+	"github.com/crossplane/crossplane-runtime/v2/pkg/fieldpath"
+
+	v1 "github.com/crossplane/function-sdk-go/proto/v1"
+)
+
+// Digest returns a short, stable digest of the supplied secret value. The
+// digest lets readers tell whether two redacted values are the same without
+// revealing either of them.
+func Digest(b []byte) string {
+	sum := sha256.Sum256(b)
+	return fmt.Sprintf("redacted:sha256:%x", sum[:8])
+}
+
+// A FieldPath identifies a secret-bearing field of a resource.
+type FieldPath struct {
+	// APIVersion of the resources the field path applies to. Empty matches
+	// all API versions.
+	APIVersion string
+
+	// Kind of the resources the field path applies to. Empty matches all
+	// kinds.
+	Kind string
+
+	// Path to the field, e.g. data or spec.forProvider.password. Wildcards
+	// like data[*] are supported.
+	Path string
+}
+
+// Options configure redaction.
+type Options struct {
+	// FieldPaths that should be redacted in addition to credentials and
+	// connection details.
+	FieldPaths []FieldPath
+}
+
+// An Option configures redaction.
+type Option func(o *Options)
+
+// WithFieldPaths redacts the supplied field paths of any resource with the
+// supplied apiVersion and kind. Empty apiVersion or kind match any resource.
+func WithFieldPaths(apiVersion, kind string, paths ...string) Option {
+	return func(o *Options) {
+		for _, p := range paths {
+			o.FieldPaths = append(o.FieldPaths, FieldPath{APIVersion: apiVersion, Kind: kind, Path: p})
+		}
+	}
+}
+
+// WithSecretData redacts the data and stringData fields of Kubernetes Secrets.
+func WithSecretData() Option {
+	return WithFieldPaths("v1", "Secret", "data", "stringData")
+}
+
+// Request returns a deep copy of the supplied RunFunctionRequest with all
+// secret-bearing fields replaced by a digest. Credential data and resource
+// connection details are always redacted. Use options to redact other fields.
+func Request(req *v1.RunFunctionRequest, o ...Option) *v1.RunFunctionRequest {
+	if req == nil {
+		return nil
+	}
+	opts := newOptions(o)
+	out := proto.Clone(req).(*v1.RunFunctionRequest) //nolint:forcetypeassert // Clone always returns the same type.
+
+	for _, c := range out.GetCredentials() {
+		redactBytes(c.GetCredentialData().GetData())
+	}
+	opts.state(out.GetObserved())
+	opts.state(out.GetDesired())
+	for _, rs := range out.GetExtraResources() {
+		for _, r := range rs.GetItems() {
+			opts.resource(r)
+		}
+	}
+	for _, rs := range out.GetRequiredResources() {
+		for _, r := range rs.GetItems() {
+			opts.resource(r)
+		}
+	}
+	return out
+}
+
+// Response returns a deep copy of the supplied RunFunctionResponse with all
+// secret-bearing fields replaced by a digest. Resource connection details are
+// always redacted. Use options to redact other fields.
+func Response(rsp *v1.RunFunctionResponse, o ...Option) *v1.RunFunctionResponse {
+	if rsp == nil {
+		return nil
+	}
+	opts := newOptions(o)
+	out := proto.Clone(rsp).(*v1.RunFunctionResponse) //nolint:forcetypeassert // Clone always returns the same type.
+
+	opts.state(out.GetDesired())
+	return out
+}
+
+func newOptions(o []Option) *Options {
+	opts := &Options{}
+	for _, fn := range o {
+		fn(opts)
+	}
+	return opts
+}
+
+func (o *Options) state(s *v1.State) {
+	o.resource(s.GetComposite())
+	for _, r := range s.GetResources() {
+		o.resource(r)
+	}
+}
+
+func (o *Options) resource(r *v1.Resource) {
+	if r == nil {
+		return
+	}
+	redactBytes(r.GetConnectionDetails())
+	if r.GetResource() == nil {
+		return
+	}
+	r.Resource = o.fields(r.GetResource())
+}
+
+// fields redacts the configured field paths of the supplied resource. If the
+// resource can't be redacted it's replaced entirely, so we never leak a
+// secret we were asked to redact.
+func (o *Options) fields(s *structpb.Struct) *structpb.Struct {
+	f := s.GetFields()
+	apiVersion, kind := f["apiVersion"].GetStringValue(), f["kind"].GetStringValue()
+
+	var p *fieldpath.Paved
+	for _, fp := range o.FieldPaths {
+		if fp.APIVersion != "" && fp.APIVersion != apiVersion {
+			continue
+		}
+		if fp.Kind != "" && fp.Kind != kind {
+			continue
+		}
+		if p == nil {
+			p = fieldpath.Pave(s.AsMap())
+		}
+		paths, err := p.ExpandWildcards(fp.Path)
+		if err != nil {
+			return opaque(s)
+		}
+		for _, path := range paths {
+			v, err := p.GetValue(path)
+			if err != nil {
+				return opaque(s)
+			}
+			if err := p.SetValue(path, redactValue(v)); err != nil {
+				return opaque(s)
+			}
+		}
+	}
+	if p == nil {
+		return s
+	}
+
+	out, err := structpb.NewStruct(p.UnstructuredContent())
+	if err != nil {
+		return opaque(s)
+	}
+	return out
+}
+
+// redactValue replaces every leaf of the supplied value with its digest. Map
+// keys and list lengths are preserved.
+func redactValue(v any) any {
+	switch t := v.(type) {
+	case map[string]any:
+		out := make(map[string]any, len(t))
+		for k, e := range t {
+			out[k] = redactValue(e)
+		}
+		return out
+	case []any:
+		out := make([]any, len(t))
+		for i, e := range t {
+			out[i] = redactValue(e)
+		}
+		return out
+	case string:
+		return Digest([]byte(t))
+	default:
+		b, _ := json.Marshal(t) //nolint:errchkjson // Unstructured values are always valid JSON.
+		return Digest(b)
+	}
+}
+
+// opaque returns a resource that contains only the apiVersion and kind of the
+// supplied resource.
+func opaque(s *structpb.Struct) *structpb.Struct {
+	out := &structpb.Struct{Fields: map[string]*structpb.Value{}}
+	for _, k := range []string{"apiVersion", "kind"} {
+		if v, ok := s.GetFields()[k]; ok {
+			out.Fields[k] = v
+		}
+	}
+	return out
+}
+
+func redactBytes(m map[string][]byte) {
+	for k, v := range m {
+		m[k] = []byte(Digest(v))
+	}
+}
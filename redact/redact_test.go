@@ -0,0 +1,218 @@
+/*
+Copyright 2026 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package redact
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/testing/protocmp"
+
+	v1 "github.com/crossplane/function-sdk-go/proto/v1"
+	"github.com/crossplane/function-sdk-go/resource"
+)
+
+func TestRequest(t *testing.T) {
+	type args struct {
+		req *v1.RunFunctionRequest
+		o   []Option
+	}
+
+	cases := map[string]struct {
+		reason string
+		args   args
+		want   *v1.RunFunctionRequest
+	}{
+		"Nil": {
+			reason: "We should return nil if the request is nil.",
+			args:   args{},
+			want:   nil,
+		},
+		"CredentialsAndConnectionDetails": {
+			reason: "We should always redact credential data and connection details.",
+			args: args{
+				req: &v1.RunFunctionRequest{
+					Observed: &v1.State{
+						Composite: &v1.Resource{
+							ConnectionDetails: map[string][]byte{"password": []byte("hunter2")},
+						},
+						Resources: map[string]*v1.Resource{
+							"db": {ConnectionDetails: map[string][]byte{"password": []byte("hunter2")}},
+						},
+					},
+					Credentials: map[string]*v1.Credentials{
+						"cloud": {Source: &v1.Credentials_CredentialData{CredentialData: &v1.CredentialData{
+							Data: map[string][]byte{"key": []byte("secret")},
+						}}},
+					},
+					RequiredResources: map[string]*v1.Resources{
+						"extra": {Items: []*v1.Resource{{ConnectionDetails: map[string][]byte{"token": []byte("t")}}}},
+					},
+				},
+			},
+			want: &v1.RunFunctionRequest{
+				Observed: &v1.State{
+					Composite: &v1.Resource{
+						ConnectionDetails: map[string][]byte{"password": []byte(Digest([]byte("hunter2")))},
+					},
+					Resources: map[string]*v1.Resource{
+						"db": {ConnectionDetails: map[string][]byte{"password": []byte(Digest([]byte("hunter2")))}},
+					},
+				},
+				Credentials: map[string]*v1.Credentials{
+					"cloud": {Source: &v1.Credentials_CredentialData{CredentialData: &v1.CredentialData{
+						Data: map[string][]byte{"key": []byte(Digest([]byte("secret")))},
+					}}},
+				},
+				RequiredResources: map[string]*v1.Resources{
+					"extra": {Items: []*v1.Resource{{ConnectionDetails: map[string][]byte{"token": []byte(Digest([]byte("t")))}}}},
+				},
+			},
+		},
+		"SecretData": {
+			reason: "We should redact the data of Secrets, but not other resources, when asked to.",
+			args: args{
+				req: &v1.RunFunctionRequest{
+					RequiredResources: map[string]*v1.Resources{
+						"secrets": {Items: []*v1.Resource{
+							{Resource: resource.MustStructJSON(`{"apiVersion":"v1","kind":"Secret","data":{"password":"aHVudGVyMg=="}}`)},
+							{Resource: resource.MustStructJSON(`{"apiVersion":"v1","kind":"ConfigMap","data":{"password":"hunter2"}}`)},
+						}},
+					},
+				},
+				o: []Option{WithSecretData()},
+			},
+			want: &v1.RunFunctionRequest{
+				RequiredResources: map[string]*v1.Resources{
+					"secrets": {Items: []*v1.Resource{
+						{Resource: resource.MustStructJSON(`{"apiVersion":"v1","kind":"Secret","data":{"password":"` + Digest([]byte("aHVudGVyMg==")) + `"}}`)},
+						{Resource: resource.MustStructJSON(`{"apiVersion":"v1","kind":"ConfigMap","data":{"password":"hunter2"}}`)},
+					}},
+				},
+			},
+		},
+		"Wildcard": {
+			reason: "We should redact field paths containing wildcards, for resources of any kind.",
+			args: args{
+				req: &v1.RunFunctionRequest{
+					Desired: &v1.State{
+						Composite: &v1.Resource{
+							Resource: resource.MustStructJSON(`{"apiVersion":"example.org/v1","kind":"XR","spec":{"users":[{"name":"a","password":"p"}]}}`),
+						},
+					},
+				},
+				o: []Option{WithFieldPaths("", "", "spec.users[*].password")},
+			},
+			want: &v1.RunFunctionRequest{
+				Desired: &v1.State{
+					Composite: &v1.Resource{
+						Resource: resource.MustStructJSON(`{"apiVersion":"example.org/v1","kind":"XR","spec":{"users":[{"name":"a","password":"` + Digest([]byte("p")) + `"}]}}`),
+					},
+				},
+			},
+		},
+		"InvalidFieldPath": {
+			reason: "We should redact an entire resource if we can't redact the requested field path.",
+			args: args{
+				req: &v1.RunFunctionRequest{
+					Desired: &v1.State{
+						Composite: &v1.Resource{
+							Resource: resource.MustStructJSON(`{"apiVersion":"example.org/v1","kind":"XR","spec":{"password":"p"}}`),
+						},
+					},
+				},
+				o: []Option{WithFieldPaths("", "", "spec[")},
+			},
+			want: &v1.RunFunctionRequest{
+				Desired: &v1.State{
+					Composite: &v1.Resource{
+						Resource: resource.MustStructJSON(`{"apiVersion":"example.org/v1","kind":"XR"}`),
+					},
+				},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			before := proto.Clone(tc.args.req)
+
+			got := Request(tc.args.req, tc.args.o...)
+			if diff := cmp.Diff(tc.want, got, protocmp.Transform()); diff != "" {
+				t.Errorf("\n%s\nRequest(...): -want, +got:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(before, tc.args.req, protocmp.Transform()); diff != "" {
+				t.Errorf("\n%s\nRequest(...): must not modify the supplied request: -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestResponse(t *testing.T) {
+	type args struct {
+		rsp *v1.RunFunctionResponse
+		o   []Option
+	}
+
+	cases := map[string]struct {
+		reason string
+		args   args
+		want   *v1.RunFunctionResponse
+	}{
+		"Nil": {
+			reason: "We should return nil if the response is nil.",
+			args:   args{},
+			want:   nil,
+		},
+		"DesiredResources": {
+			reason: "We should redact the connection details and configured fields of desired resources.",
+			args: args{
+				rsp: &v1.RunFunctionResponse{
+					Desired: &v1.State{
+						Resources: map[string]*v1.Resource{
+							"secret": {
+								Resource:          resource.MustStructJSON(`{"apiVersion":"v1","kind":"Secret","stringData":{"password":"hunter2"}}`),
+								ConnectionDetails: map[string][]byte{"password": []byte("hunter2")},
+							},
+						},
+					},
+				},
+				o: []Option{WithSecretData()},
+			},
+			want: &v1.RunFunctionResponse{
+				Desired: &v1.State{
+					Resources: map[string]*v1.Resource{
+						"secret": {
+							Resource:          resource.MustStructJSON(`{"apiVersion":"v1","kind":"Secret","stringData":{"password":"` + Digest([]byte("hunter2")) + `"}}`),
+							ConnectionDetails: map[string][]byte{"password": []byte(Digest([]byte("hunter2")))},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := Response(tc.args.rsp, tc.args.o...)
+			if diff := cmp.Diff(tc.want, got, protocmp.Transform()); diff != "" {
+				t.Errorf("\n%s\nResponse(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,132 @@
+/*
+Copyright 2026 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package render
+
+import (
+	"context"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/structpb"
+
+	"github.com/crossplane/function-sdk-go/errors"
+	v1 "github.com/crossplane/function-sdk-go/proto/v1"
+)
+
+// A Step of a Composition's function pipeline.
+type Step struct {
+	// Name of the step.
+	Name string
+
+	// Function called by the step. Use Remote to call a Function served
+	// over gRPC.
+	Function Function
+
+	// Input passed to the Function, if any.
+	Input *structpb.Struct
+
+	// Credentials passed to the Function, if any.
+	Credentials map[string]*v1.Credentials
+}
+
+// A StepOutput is the result of running a pipeline step.
+type StepOutput struct {
+	// Name of the step.
+	Name string
+
+	// Output of the step's Function.
+	*Output
+}
+
+// A PipelineOutput is the result of running a pipeline.
+type PipelineOutput struct {
+	// Steps that were run, in order.
+	Steps []StepOutput
+
+	// Desired state returned by the last step that was run.
+	Desired *v1.State
+
+	// Context returned by the last step that was run.
+	Context *structpb.Struct
+
+	// Results returned by all steps that were run, in order.
+	Results []*v1.Result
+
+	// Conditions returned by all steps that were run, in order.
+	Conditions []*v1.Condition
+
+	// FatalStep is the name of the step that returned a fatal result, if
+	// any. The pipeline stops at the first fatal result.
+	FatalStep string
+}
+
+// A Pipeline runs a series of Functions the way Crossplane runs a
+// Composition's function pipeline.
+type Pipeline struct {
+	steps []Step
+	o     []RendererOption
+}
+
+// NewPipeline returns a Pipeline that runs the supplied steps in order. Each
+// step's Function is rendered using a Renderer configured with the supplied
+// options, so each step's requirements are satisfied before the next step
+// runs.
+func NewPipeline(steps []Step, o ...RendererOption) *Pipeline {
+	return &Pipeline{steps: steps, o: o}
+}
+
+// Run the pipeline. The supplied request provides the observed state, and the
+// initial desired state and context. Each step is called with the desired
+// state and context returned by the previous step. Run stops at the first step
+// that returns a fatal result, and returns an error if any step's Function
+// returns an error. The supplied request isn't modified.
+func (p *Pipeline) Run(ctx context.Context, req *v1.RunFunctionRequest) (*PipelineOutput, error) {
+	out := &PipelineOutput{
+		Desired: req.GetDesired(),
+		Context: req.GetContext(),
+	}
+
+	for _, s := range p.steps {
+		sreq := proto.Clone(req).(*v1.RunFunctionRequest) //nolint:forcetypeassert // Clone always returns the same type.
+		sreq.Desired = out.Desired
+		sreq.Context = out.Context
+		sreq.Input = s.Input
+		sreq.Credentials = s.Credentials
+
+		so, err := NewRenderer(s.Function, p.o...).Render(ctx, sreq)
+		if err != nil {
+			return nil, errors.Wrapf(err, "cannot run pipeline step %q", s.Name)
+		}
+		out.Steps = append(out.Steps, StepOutput{Name: s.Name, Output: so})
+
+		rsp := so.Response
+		out.Results = append(out.Results, rsp.GetResults()...)
+		out.Conditions = append(out.Conditions, rsp.GetConditions()...)
+
+		if HasFatalResult(rsp) {
+			out.FatalStep = s.Name
+			return out, nil
+		}
+
+		// Like Crossplane, we pass the desired state and context returned
+		// by this step to the next step, even if they're empty. A step
+		// that returns no context clears it.
+		out.Desired = rsp.GetDesired()
+		out.Context = rsp.GetContext()
+	}
+
+	return out, nil
+}
@@ -0,0 +1,196 @@
+/*
+Copyright 2026 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package render
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/testing/protocmp"
+	"google.golang.org/protobuf/types/known/structpb"
+
+	"github.com/crossplane/crossplane-runtime/v2/pkg/test"
+
+	"github.com/crossplane/function-sdk-go/errors"
+	v1 "github.com/crossplane/function-sdk-go/proto/v1"
+	"github.com/crossplane/function-sdk-go/resource"
+)
+
+// compose returns a Function that adds a desired resource with the supplied
+// name, and records the names of the desired resources and the context keys
+// it was called with in a result.
+func compose(name string) Function {
+	return FunctionFn(func(_ context.Context, req *v1.RunFunctionRequest) (*v1.RunFunctionResponse, error) {
+		rsp := &v1.RunFunctionResponse{
+			Desired: proto.Clone(req.GetDesired()).(*v1.State),        //nolint:forcetypeassert // Clone always returns the same type.
+			Context: proto.Clone(req.GetContext()).(*structpb.Struct), //nolint:forcetypeassert // Clone always returns the same type.
+		}
+		if rsp.Desired == nil {
+			rsp.Desired = &v1.State{}
+		}
+		if rsp.Desired.Resources == nil {
+			rsp.Desired.Resources = map[string]*v1.Resource{}
+		}
+		if rsp.Context == nil {
+			rsp.Context = &structpb.Struct{Fields: map[string]*structpb.Value{}}
+		}
+
+		msg := fmt.Sprintf("%s: resources=%d context=%d input=%s", name,
+			len(req.GetDesired().GetResources()),
+			len(req.GetContext().GetFields()),
+			req.GetInput().GetFields()["value"].GetStringValue(),
+		)
+		rsp.Results = []*v1.Result{{Severity: v1.Severity_SEVERITY_NORMAL, Message: msg}}
+		rsp.Desired.Resources[name] = &v1.Resource{Resource: resource.MustStructJSON(`{"apiVersion":"v1","kind":"ConfigMap"}`)}
+		rsp.Context.Fields[name] = structpb.NewBoolValue(true)
+		return rsp, nil
+	})
+}
+
+func TestPipelineRun(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	fatal := FunctionFn(func(_ context.Context, _ *v1.RunFunctionRequest) (*v1.RunFunctionResponse, error) {
+		return &v1.RunFunctionResponse{Results: []*v1.Result{{Severity: v1.Severity_SEVERITY_FATAL, Message: "oh no"}}}, nil
+	})
+
+	type want struct {
+		steps     []string
+		results   []*v1.Result
+		desired   []string
+		context   []string
+		fatalStep string
+		err       error
+	}
+
+	cases := map[string]struct {
+		reason string
+		steps  []Step
+		want   want
+	}{
+		"ThreadsState": {
+			reason: "Each step should be called with the desired state and context returned by the previous step.",
+			steps: []Step{
+				{Name: "first", Function: compose("a"), Input: resource.MustStructJSON(`{"value":"one"}`)},
+				{Name: "second", Function: compose("b"), Input: resource.MustStructJSON(`{"value":"two"}`)},
+			},
+			want: want{
+				steps: []string{"first", "second"},
+				results: []*v1.Result{
+					{Severity: v1.Severity_SEVERITY_NORMAL, Message: "a: resources=0 context=0 input=one"},
+					{Severity: v1.Severity_SEVERITY_NORMAL, Message: "b: resources=1 context=1 input=two"},
+				},
+				desired: []string{"a", "b"},
+				context: []string{"a", "b"},
+			},
+		},
+		"NilContextClearsContext": {
+			reason: "A step that returns no context should clear the context passed to the next step.",
+			steps: []Step{
+				{Name: "first", Function: compose("a")},
+				{Name: "forgetful", Function: FunctionFn(func(_ context.Context, req *v1.RunFunctionRequest) (*v1.RunFunctionResponse, error) {
+					return &v1.RunFunctionResponse{Desired: req.GetDesired()}, nil
+				})},
+				{Name: "second", Function: compose("b")},
+			},
+			want: want{
+				steps: []string{"first", "forgetful", "second"},
+				results: []*v1.Result{
+					{Severity: v1.Severity_SEVERITY_NORMAL, Message: "a: resources=0 context=0 input="},
+					{Severity: v1.Severity_SEVERITY_NORMAL, Message: "b: resources=1 context=0 input="},
+				},
+				desired: []string{"a", "b"},
+				context: []string{"b"},
+			},
+		},
+		"StopsOnFatal": {
+			reason: "The pipeline should stop at the first step that returns a fatal result.",
+			steps: []Step{
+				{Name: "first", Function: compose("a")},
+				{Name: "fatal", Function: fatal},
+				{Name: "never", Function: compose("b")},
+			},
+			want: want{
+				steps: []string{"first", "fatal"},
+				results: []*v1.Result{
+					{Severity: v1.Severity_SEVERITY_NORMAL, Message: "a: resources=0 context=0 input="},
+					{Severity: v1.Severity_SEVERITY_FATAL, Message: "oh no"},
+				},
+				desired:   []string{"a"},
+				context:   []string{"a"},
+				fatalStep: "fatal",
+			},
+		},
+		"FunctionError": {
+			reason: "We should return an error if a step's Function returns an error.",
+			steps: []Step{
+				{Name: "broken", Function: FunctionFn(func(_ context.Context, _ *v1.RunFunctionRequest) (*v1.RunFunctionResponse, error) {
+					return nil, errBoom
+				})},
+			},
+			want: want{
+				err: errors.Wrap(errors.Wrap(errBoom, "cannot run Function (iteration 1)"), `cannot run pipeline step "broken"`),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			out, err := NewPipeline(tc.steps).Run(context.Background(), &v1.RunFunctionRequest{})
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Fatalf("\n%s\nRun(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+			if err != nil {
+				return
+			}
+
+			steps := make([]string, 0, len(out.Steps))
+			for _, s := range out.Steps {
+				steps = append(steps, s.Name)
+			}
+			desired := make([]string, 0)
+			for n := range out.Desired.GetResources() {
+				desired = append(desired, n)
+			}
+			keys := make([]string, 0)
+			for k := range out.Context.GetFields() {
+				keys = append(keys, k)
+			}
+			sortStrings := cmpopts.SortSlices(func(a, b string) bool { return a < b })
+
+			if diff := cmp.Diff(tc.want.steps, steps); diff != "" {
+				t.Errorf("\n%s\nRun(...): -want steps, +got steps:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.results, out.Results, protocmp.Transform()); diff != "" {
+				t.Errorf("\n%s\nRun(...): -want results, +got results:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.desired, desired, sortStrings); diff != "" {
+				t.Errorf("\n%s\nRun(...): -want desired resources, +got desired resources:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.context, keys, sortStrings); diff != "" {
+				t.Errorf("\n%s\nRun(...): -want context keys, +got context keys:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.fatalStep, out.FatalStep); diff != "" {
+				t.Errorf("\n%s\nRun(...): -want fatal step, +got fatal step:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
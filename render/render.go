@@ -0,0 +1,211 @@
+/*
+Copyright 2026 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package render runs a Function locally, the way Crossplane does. It
+// satisfies the Function's resource and schema requirements from a Store, and
+// calls the Function repeatedly until its requirements stabilize.
+package render
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/crossplane/function-sdk-go/errors"
+	v1 "github.com/crossplane/function-sdk-go/proto/v1"
+)
+
+// DefaultMaxIterations is the default number of times a Function is called
+// while waiting for its requirements to stabilize. It matches Crossplane.
+const DefaultMaxIterations = 5
+
+// A Function runs a RunFunctionRequest. Any v1.FunctionRunnerServiceServer is
+// a Function, so a Function can be rendered in-process. Use Remote to render a
+// Function served over gRPC.
+type Function interface {
+	RunFunction(ctx context.Context, req *v1.RunFunctionRequest) (*v1.RunFunctionResponse, error)
+}
+
+// A FunctionFn is a function that satisfies Function.
+type FunctionFn func(ctx context.Context, req *v1.RunFunctionRequest) (*v1.RunFunctionResponse, error)
+
+// RunFunction calls the FunctionFn.
+func (fn FunctionFn) RunFunction(ctx context.Context, req *v1.RunFunctionRequest) (*v1.RunFunctionResponse, error) {
+	return fn(ctx, req)
+}
+
+// Remote returns a Function that sends requests to a Function served over the
+// supplied gRPC connection.
+func Remote(conn grpc.ClientConnInterface) Function {
+	c := v1.NewFunctionRunnerServiceClient(conn)
+	return FunctionFn(func(ctx context.Context, req *v1.RunFunctionRequest) (*v1.RunFunctionResponse, error) {
+		return c.RunFunction(ctx, req)
+	})
+}
+
+// An Output is the result of rendering a Function.
+type Output struct {
+	// Request is the last request sent to the Function, including any
+	// required resources and schemas.
+	Request *v1.RunFunctionRequest
+
+	// Response is the Function's response to the last request.
+	Response *v1.RunFunctionResponse
+
+	// Iterations is the number of times the Function was called.
+	Iterations int
+}
+
+// A Renderer renders a Function.
+type Renderer struct {
+	fn            Function
+	store         Store
+	maxIterations int
+}
+
+// A RendererOption configures a Renderer.
+type RendererOption func(r *Renderer)
+
+// WithStore configures the Store used to satisfy the Function's requirements.
+// By default a Renderer uses an empty MemoryStore.
+func WithStore(s Store) RendererOption {
+	return func(r *Renderer) {
+		r.store = s
+	}
+}
+
+// WithMaxIterations configures how many times a Renderer will call the
+// Function while waiting for its requirements to stabilize.
+func WithMaxIterations(n int) RendererOption {
+	return func(r *Renderer) {
+		r.maxIterations = n
+	}
+}
+
+// NewRenderer returns a Renderer that renders the supplied Function.
+func NewRenderer(fn Function, o ...RendererOption) *Renderer {
+	r := &Renderer{
+		fn:            fn,
+		store:         &MemoryStore{},
+		maxIterations: DefaultMaxIterations,
+	}
+	for _, ro := range o {
+		ro(r)
+	}
+	return r
+}
+
+// Render calls the Function with the supplied request. If the Function
+// returns requirements, Render satisfies them from its Store and calls the
+// Function again. It returns when the Function's requirements are the same as
+// the previous call's, when the Function returns a fatal result, or with an
+// error if the requirements don't stabilize within the maximum number of
+// iterations. The supplied request isn't modified.
+func (r *Renderer) Render(ctx context.Context, req *v1.RunFunctionRequest) (*Output, error) {
+	req = proto.Clone(req).(*v1.RunFunctionRequest) //nolint:forcetypeassert // Clone always returns the same type.
+
+	var requirements *v1.Requirements
+	for i := 1; i <= r.maxIterations; i++ {
+		rsp, err := r.fn.RunFunction(ctx, req)
+		if err != nil {
+			return nil, errors.Wrapf(err, "cannot run Function (iteration %d)", i)
+		}
+		out := &Output{Request: req, Response: rsp, Iterations: i}
+
+		if HasFatalResult(rsp) {
+			return out, nil
+		}
+
+		if !hasRequirements(rsp.GetRequirements()) {
+			return out, nil
+		}
+
+		// The Function's requirements stabilized. The request it was
+		// just called with satisfied them.
+		if proto.Equal(requirements, rsp.GetRequirements()) {
+			return out, nil
+		}
+		requirements = rsp.GetRequirements()
+
+		next := proto.Clone(req).(*v1.RunFunctionRequest) //nolint:forcetypeassert // Clone always returns the same type.
+		if err := Satisfy(ctx, r.store, requirements, next); err != nil {
+			return nil, errors.Wrapf(err, "cannot satisfy Function requirements (iteration %d)", i)
+		}
+		req = next
+	}
+
+	return nil, errors.Errorf("Function requirements didn't stabilize after %d iterations", r.maxIterations)
+}
+
+// Satisfy the supplied requirements from the supplied Store, by setting the
+// required resources and schemas of the supplied request. Resources required
+// using the deprecated extra_resources requirement are set as extra resources.
+func Satisfy(ctx context.Context, s Store, rq *v1.Requirements, req *v1.RunFunctionRequest) error {
+	req.ExtraResources = nil
+	req.RequiredResources = nil
+	req.RequiredSchemas = nil
+
+	if len(rq.GetExtraResources()) > 0 {
+		req.ExtraResources = make(map[string]*v1.Resources, len(rq.GetExtraResources()))
+	}
+	for name, sel := range rq.GetExtraResources() {
+		items, err := s.GetResources(ctx, sel)
+		if err != nil {
+			return errors.Wrapf(err, "cannot get extra resources %q", name)
+		}
+		req.ExtraResources[name] = &v1.Resources{Items: items}
+	}
+
+	if len(rq.GetResources()) > 0 {
+		req.RequiredResources = make(map[string]*v1.Resources, len(rq.GetResources()))
+	}
+	for name, sel := range rq.GetResources() {
+		items, err := s.GetResources(ctx, sel)
+		if err != nil {
+			return errors.Wrapf(err, "cannot get required resources %q", name)
+		}
+		req.RequiredResources[name] = &v1.Resources{Items: items}
+	}
+
+	if len(rq.GetSchemas()) > 0 {
+		req.RequiredSchemas = make(map[string]*v1.Schema, len(rq.GetSchemas()))
+	}
+	for name, sel := range rq.GetSchemas() {
+		sc, err := s.GetSchema(ctx, sel)
+		if err != nil {
+			return errors.Wrapf(err, "cannot get required schema %q", name)
+		}
+		req.RequiredSchemas[name] = sc
+	}
+
+	return nil
+}
+
+// HasFatalResult returns true if the supplied response includes a fatal
+// result.
+func HasFatalResult(rsp *v1.RunFunctionResponse) bool {
+	for _, r := range rsp.GetResults() {
+		if r.GetSeverity() == v1.Severity_SEVERITY_FATAL {
+			return true
+		}
+	}
+	return false
+}
+
+func hasRequirements(rq *v1.Requirements) bool {
+	return len(rq.GetExtraResources())+len(rq.GetResources())+len(rq.GetSchemas()) > 0
+}
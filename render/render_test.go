@@ -0,0 +1,176 @@
+/*
+Copyright 2026 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package render
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/protobuf/testing/protocmp"
+	"google.golang.org/protobuf/types/known/structpb"
+	"k8s.io/utils/ptr"
+
+	"github.com/crossplane/crossplane-runtime/v2/pkg/test"
+
+	"github.com/crossplane/function-sdk-go/errors"
+	v1 "github.com/crossplane/function-sdk-go/proto/v1"
+	"github.com/crossplane/function-sdk-go/resource"
+)
+
+var cm = resource.MustStructJSON(`{"apiVersion":"v1","kind":"ConfigMap","metadata":{"name":"cool","namespace":"default"}}`)
+
+// requireConfigMap returns a Function that requires a ConfigMap, and reports
+// how many it received.
+func requireConfigMap() Function {
+	return FunctionFn(func(_ context.Context, req *v1.RunFunctionRequest) (*v1.RunFunctionResponse, error) {
+		n := len(req.GetRequiredResources()["cm"].GetItems())
+		return &v1.RunFunctionResponse{
+			Results: []*v1.Result{{Severity: v1.Severity_SEVERITY_NORMAL, Message: fmt.Sprintf("got %d", n)}},
+			Requirements: &v1.Requirements{
+				Resources: map[string]*v1.ResourceSelector{
+					"cm": {ApiVersion: "v1", Kind: "ConfigMap", Match: &v1.ResourceSelector_MatchName{MatchName: "cool"}, Namespace: ptr.To("default")},
+				},
+			},
+		}, nil
+	})
+}
+
+func TestRender(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	type args struct {
+		fn Function
+		o  []RendererOption
+	}
+	type want struct {
+		rsp        *v1.RunFunctionResponse
+		iterations int
+		err        error
+	}
+
+	cases := map[string]struct {
+		reason string
+		args   args
+		want   want
+	}{
+		"NoRequirements": {
+			reason: "We should call the Function once if it has no requirements.",
+			args: args{
+				fn: FunctionFn(func(_ context.Context, _ *v1.RunFunctionRequest) (*v1.RunFunctionResponse, error) {
+					return &v1.RunFunctionResponse{}, nil
+				}),
+			},
+			want: want{
+				rsp:        &v1.RunFunctionResponse{},
+				iterations: 1,
+			},
+		},
+		"RequirementsStabilize": {
+			reason: "We should call the Function again with its requirements satisfied, and return once they stabilize.",
+			args: args{
+				fn: requireConfigMap(),
+				o:  []RendererOption{WithStore(&MemoryStore{Resources: []*structpb.Struct{cm}})},
+			},
+			want: want{
+				rsp: &v1.RunFunctionResponse{
+					Results: []*v1.Result{{Severity: v1.Severity_SEVERITY_NORMAL, Message: "got 1"}},
+					Requirements: &v1.Requirements{
+						Resources: map[string]*v1.ResourceSelector{
+							"cm": {ApiVersion: "v1", Kind: "ConfigMap", Match: &v1.ResourceSelector_MatchName{MatchName: "cool"}, Namespace: ptr.To("default")},
+						},
+					},
+				},
+				iterations: 2,
+			},
+		},
+		"RequirementsNeverStabilize": {
+			reason: "We should return an error if the Function's requirements never stabilize.",
+			args: args{
+				fn: FunctionFn(func(_ context.Context, req *v1.RunFunctionRequest) (*v1.RunFunctionResponse, error) {
+					// Require one more schema each time we're called.
+					schemas := map[string]*v1.SchemaSelector{}
+					for i := range len(req.GetRequiredSchemas()) + 1 {
+						schemas[fmt.Sprintf("s%d", i)] = &v1.SchemaSelector{ApiVersion: "v1", Kind: "ConfigMap"}
+					}
+					return &v1.RunFunctionResponse{Requirements: &v1.Requirements{Schemas: schemas}}, nil
+				}),
+				o: []RendererOption{WithMaxIterations(3)},
+			},
+			want: want{
+				err: errors.New("Function requirements didn't stabilize after 3 iterations"),
+			},
+		},
+		"FatalResult": {
+			reason: "We should stop calling the Function once it returns a fatal result.",
+			args: args{
+				fn: FunctionFn(func(_ context.Context, _ *v1.RunFunctionRequest) (*v1.RunFunctionResponse, error) {
+					return &v1.RunFunctionResponse{
+						Results: []*v1.Result{{Severity: v1.Severity_SEVERITY_FATAL}},
+						Requirements: &v1.Requirements{
+							Schemas: map[string]*v1.SchemaSelector{"s": {ApiVersion: "v1", Kind: "ConfigMap"}},
+						},
+					}, nil
+				}),
+			},
+			want: want{
+				rsp: &v1.RunFunctionResponse{
+					Results: []*v1.Result{{Severity: v1.Severity_SEVERITY_FATAL}},
+					Requirements: &v1.Requirements{
+						Schemas: map[string]*v1.SchemaSelector{"s": {ApiVersion: "v1", Kind: "ConfigMap"}},
+					},
+				},
+				iterations: 1,
+			},
+		},
+		"FunctionError": {
+			reason: "We should return an error if the Function returns an error.",
+			args: args{
+				fn: FunctionFn(func(_ context.Context, _ *v1.RunFunctionRequest) (*v1.RunFunctionResponse, error) {
+					return nil, errBoom
+				}),
+			},
+			want: want{
+				err: errors.Wrap(errBoom, "cannot run Function (iteration 1)"),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			req := &v1.RunFunctionRequest{}
+			out, err := NewRenderer(tc.args.fn, tc.args.o...).Render(context.Background(), req)
+
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Fatalf("\n%s\nRender(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+			if err != nil {
+				return
+			}
+			if diff := cmp.Diff(tc.want.rsp, out.Response, protocmp.Transform()); diff != "" {
+				t.Errorf("\n%s\nRender(...): -want rsp, +got rsp:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.iterations, out.Iterations); diff != "" {
+				t.Errorf("\n%s\nRender(...): -want iterations, +got iterations:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(&v1.RunFunctionRequest{}, req, protocmp.Transform()); diff != "" {
+				t.Errorf("\n%s\nRender(...): must not modify the supplied request: -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
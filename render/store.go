@@ -0,0 +1,118 @@
+/*
+Copyright 2026 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package render
+
+import (
+	"context"
+	"sort"
+
+	"google.golang.org/protobuf/types/known/structpb"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/crossplane/function-sdk-go/errors"
+	v1 "github.com/crossplane/function-sdk-go/proto/v1"
+)
+
+// A Store satisfies a Function's requirements.
+type Store interface {
+	// GetResources returns the resources that match the supplied selector.
+	GetResources(ctx context.Context, sel *v1.ResourceSelector) ([]*v1.Resource, error)
+
+	// GetSchema returns the schema that matches the supplied selector.
+	GetSchema(ctx context.Context, sel *v1.SchemaSelector) (*v1.Schema, error)
+}
+
+// A MemoryStore satisfies a Function's requirements from memory.
+type MemoryStore struct {
+	// Resources that may be required by the Function.
+	Resources []*structpb.Struct
+
+	// Schemas that may be required by the Function, keyed by the kind of
+	// resource they describe. Each schema is an OpenAPI v3 schema, like a
+	// CRD's spec.versions[].schema.openAPIV3Schema field.
+	Schemas map[schema.GroupVersionKind]*structpb.Struct
+}
+
+// GetResources returns the resources that match the supplied selector, sorted
+// by namespace and name. Selecting by name without a namespace matches only
+// cluster scoped resources. Selecting by labels without a namespace matches
+// resources in all namespaces.
+func (s *MemoryStore) GetResources(_ context.Context, sel *v1.ResourceSelector) ([]*v1.Resource, error) {
+	if sel.GetMatchName() == "" && sel.GetMatchLabels() == nil {
+		return nil, errors.New("resource selector must match by name or by labels")
+	}
+
+	out := make([]*v1.Resource, 0)
+	for _, r := range s.Resources {
+		if matches(sel, r) {
+			out = append(out, &v1.Resource{Resource: r})
+		}
+	}
+
+	sort.Slice(out, func(i, j int) bool {
+		mi, mj := metadata(out[i].GetResource()), metadata(out[j].GetResource())
+		if ns := mi["namespace"].GetStringValue(); ns != mj["namespace"].GetStringValue() {
+			return ns < mj["namespace"].GetStringValue()
+		}
+		return mi["name"].GetStringValue() < mj["name"].GetStringValue()
+	})
+
+	return out, nil
+}
+
+// GetSchema returns the schema of the selected kind. Like Crossplane, it
+// returns an empty schema if it doesn't know the kind.
+func (s *MemoryStore) GetSchema(_ context.Context, sel *v1.SchemaSelector) (*v1.Schema, error) {
+	gv, err := schema.ParseGroupVersion(sel.GetApiVersion())
+	if err != nil {
+		return nil, errors.Wrapf(err, "cannot parse apiVersion %q", sel.GetApiVersion())
+	}
+	sc, ok := s.Schemas[gv.WithKind(sel.GetKind())]
+	if !ok {
+		return &v1.Schema{}, nil
+	}
+	return &v1.Schema{OpenapiV3: sc}, nil
+}
+
+func matches(sel *v1.ResourceSelector, r *structpb.Struct) bool {
+	f := r.GetFields()
+	if f["apiVersion"].GetStringValue() != sel.GetApiVersion() || f["kind"].GetStringValue() != sel.GetKind() {
+		return false
+	}
+
+	md := metadata(r)
+	ns := md["namespace"].GetStringValue()
+
+	if name := sel.GetMatchName(); name != "" {
+		return md["name"].GetStringValue() == name && ns == sel.GetNamespace()
+	}
+
+	if sel.Namespace != nil && ns != sel.GetNamespace() {
+		return false
+	}
+	labels := md["labels"].GetStructValue().GetFields()
+	for k, v := range sel.GetMatchLabels().GetLabels() {
+		if l, ok := labels[k]; !ok || l.GetStringValue() != v {
+			return false
+		}
+	}
+	return true
+}
+
+func metadata(r *structpb.Struct) map[string]*structpb.Value {
+	return r.GetFields()["metadata"].GetStructValue().GetFields()
+}
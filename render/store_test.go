@@ -0,0 +1,150 @@
+/*
+Copyright 2026 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package render
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"google.golang.org/protobuf/testing/protocmp"
+	"google.golang.org/protobuf/types/known/structpb"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/utils/ptr"
+
+	v1 "github.com/crossplane/function-sdk-go/proto/v1"
+	"github.com/crossplane/function-sdk-go/resource"
+)
+
+func TestMemoryStoreGetResources(t *testing.T) {
+	cluster := resource.MustStructJSON(`{"apiVersion":"example.org/v1","kind":"Thing","metadata":{"name":"a","labels":{"app":"x"}}}`)
+	nsA := resource.MustStructJSON(`{"apiVersion":"example.org/v1","kind":"Thing","metadata":{"name":"a","namespace":"ns-a","labels":{"app":"x"}}}`)
+	nsB := resource.MustStructJSON(`{"apiVersion":"example.org/v1","kind":"Thing","metadata":{"name":"b","namespace":"ns-b","labels":{"app":"x","tier":"db"}}}`)
+	other := resource.MustStructJSON(`{"apiVersion":"example.org/v2","kind":"Thing","metadata":{"name":"a"}}`)
+
+	s := &MemoryStore{Resources: []*structpb.Struct{nsB, other, nsA, cluster}}
+
+	type want struct {
+		rs  []*v1.Resource
+		err error
+	}
+
+	cases := map[string]struct {
+		reason string
+		sel    *v1.ResourceSelector
+		want   want
+	}{
+		"MatchNameClusterScoped": {
+			reason: "Matching by name without a namespace should only match cluster scoped resources.",
+			sel:    &v1.ResourceSelector{ApiVersion: "example.org/v1", Kind: "Thing", Match: &v1.ResourceSelector_MatchName{MatchName: "a"}},
+			want: want{
+				rs: []*v1.Resource{{Resource: cluster}},
+			},
+		},
+		"MatchNameNamespaced": {
+			reason: "Matching by name in a namespace should only match resources in that namespace.",
+			sel:    &v1.ResourceSelector{ApiVersion: "example.org/v1", Kind: "Thing", Match: &v1.ResourceSelector_MatchName{MatchName: "a"}, Namespace: ptr.To("ns-a")},
+			want: want{
+				rs: []*v1.Resource{{Resource: nsA}},
+			},
+		},
+		"MatchLabelsAllNamespaces": {
+			reason: "Matching by labels without a namespace should match resources in all namespaces, sorted by namespace and name.",
+			sel:    &v1.ResourceSelector{ApiVersion: "example.org/v1", Kind: "Thing", Match: &v1.ResourceSelector_MatchLabels{MatchLabels: &v1.MatchLabels{Labels: map[string]string{"app": "x"}}}},
+			want: want{
+				rs: []*v1.Resource{{Resource: cluster}, {Resource: nsA}, {Resource: nsB}},
+			},
+		},
+		"MatchLabelsInNamespace": {
+			reason: "Matching by labels in a namespace should only match resources in that namespace.",
+			sel:    &v1.ResourceSelector{ApiVersion: "example.org/v1", Kind: "Thing", Match: &v1.ResourceSelector_MatchLabels{MatchLabels: &v1.MatchLabels{Labels: map[string]string{"app": "x"}}}, Namespace: ptr.To("ns-b")},
+			want: want{
+				rs: []*v1.Resource{{Resource: nsB}},
+			},
+		},
+		"MatchAllLabels": {
+			reason: "Matching by labels should only match resources with all of the labels.",
+			sel:    &v1.ResourceSelector{ApiVersion: "example.org/v1", Kind: "Thing", Match: &v1.ResourceSelector_MatchLabels{MatchLabels: &v1.MatchLabels{Labels: map[string]string{"app": "x", "tier": "db"}}}},
+			want: want{
+				rs: []*v1.Resource{{Resource: nsB}},
+			},
+		},
+		"NoMatch": {
+			reason: "We should return an empty list if no resources match.",
+			sel:    &v1.ResourceSelector{ApiVersion: "example.org/v3", Kind: "Thing", Match: &v1.ResourceSelector_MatchName{MatchName: "a"}},
+			want: want{
+				rs: []*v1.Resource{},
+			},
+		},
+		"InvalidSelector": {
+			reason: "We should return an error if the selector doesn't match by name or labels.",
+			sel:    &v1.ResourceSelector{ApiVersion: "example.org/v1", Kind: "Thing"},
+			want: want{
+				err: cmpopts.AnyError,
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got, err := s.GetResources(context.Background(), tc.sel)
+			if diff := cmp.Diff(tc.want.err, err, cmpopts.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nGetResources(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.rs, got, protocmp.Transform()); diff != "" {
+				t.Errorf("\n%s\nGetResources(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestMemoryStoreGetSchema(t *testing.T) {
+	sc := resource.MustStructJSON(`{"type":"object"}`)
+	s := &MemoryStore{Schemas: map[schema.GroupVersionKind]*structpb.Struct{
+		{Group: "example.org", Version: "v1", Kind: "Thing"}: sc,
+	}}
+
+	cases := map[string]struct {
+		reason string
+		sel    *v1.SchemaSelector
+		want   *v1.Schema
+	}{
+		"Known": {
+			reason: "We should return the schema of a known kind.",
+			sel:    &v1.SchemaSelector{ApiVersion: "example.org/v1", Kind: "Thing"},
+			want:   &v1.Schema{OpenapiV3: sc},
+		},
+		"Unknown": {
+			reason: "We should return an empty schema for an unknown kind.",
+			sel:    &v1.SchemaSelector{ApiVersion: "example.org/v2", Kind: "Thing"},
+			want:   &v1.Schema{},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got, err := s.GetSchema(context.Background(), tc.sel)
+			if err != nil {
+				t.Fatalf("\n%s\nGetSchema(...): %v", tc.reason, err)
+			}
+			if diff := cmp.Diff(tc.want, got, protocmp.Transform()); diff != "" {
+				t.Errorf("\n%s\nGetSchema(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
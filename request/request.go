@@ -23,6 +23,7 @@ import (
 	"google.golang.org/protobuf/types/known/structpb"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
 
 	"github.com/crossplane/function-sdk-go/errors"
 	v1 "github.com/crossplane/function-sdk-go/proto/v1"
@@ -36,6 +37,25 @@ func GetInput(req *v1.RunFunctionRequest, into runtime.Object) error {
 	return errors.Wrapf(resource.AsObject(req.GetInput(), into), "cannot get function input %T from %T", into, req)
 }
 
+// A Validator validates an object, returning every way in which it's invalid.
+// An *openapi.Schema is a Validator.
+type Validator interface {
+	Validate(obj map[string]any) field.ErrorList
+}
+
+// GetValidatedInput from the supplied request. Input is validated using the
+// supplied Validator, then loaded into the supplied object. If the input is
+// invalid the returned error describes every violation of the schema, with the
+// path to the field that caused it. The error is suitable for returning to
+// Crossplane using response.Fatal. Use openapi.FromCRD to load a schema from
+// the Function's input CRD.
+func GetValidatedInput(req *v1.RunFunctionRequest, s Validator, into runtime.Object) error {
+	if errs := s.Validate(req.GetInput().AsMap()); len(errs) > 0 {
+		return errors.Wrap(errs.ToAggregate(), "invalid function input")
+	}
+	return GetInput(req, into)
+}
+
 // GetContextKey gets context from the supplied key.
 func GetContextKey(req *v1.RunFunctionRequest, key string) (*structpb.Value, bool) {
 	f := req.GetContext().GetFields()
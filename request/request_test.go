@@ -23,6 +23,7 @@ import (
 	"google.golang.org/protobuf/testing/protocmp"
 	"google.golang.org/protobuf/types/known/structpb"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/util/validation/field"
 
 	v1 "github.com/crossplane/function-sdk-go/proto/v1"
 	"github.com/crossplane/function-sdk-go/resource"
@@ -30,6 +31,80 @@ import (
 	"github.com/crossplane/function-sdk-go/resource/composite"
 )
 
+// A ValidatorFn is a function that satisfies Validator.
+type ValidatorFn func(obj map[string]any) field.ErrorList
+
+// Validate the supplied object.
+func (fn ValidatorFn) Validate(obj map[string]any) field.ErrorList {
+	return fn(obj)
+}
+
+func TestGetValidatedInput(t *testing.T) {
+	s := ValidatorFn(func(obj map[string]any) field.ErrorList {
+		errs := field.ErrorList{}
+		if _, ok := obj["region"]; !ok {
+			errs = append(errs, field.Required(field.NewPath("region"), ""))
+		}
+		if _, ok := obj["regoin"]; ok {
+			errs = append(errs, field.Forbidden(field.NewPath("regoin"), "unknown field"))
+		}
+		return errs
+	})
+
+	type want struct {
+		in  *unstructured.Unstructured
+		err string
+	}
+
+	cases := map[string]struct {
+		reason string
+		req    *v1.RunFunctionRequest
+		want   want
+	}{
+		"Valid": {
+			reason: "Valid input should be loaded into the supplied object.",
+			req: &v1.RunFunctionRequest{
+				Input: resource.MustStructJSON(`{"apiVersion": "example.org/v1", "kind": "Input", "region": "us-east-1"}`),
+			},
+			want: want{
+				in: &unstructured.Unstructured{Object: map[string]any{
+					"apiVersion": "example.org/v1",
+					"kind":       "Input",
+					"region":     "us-east-1",
+				}},
+			},
+		},
+		"Invalid": {
+			reason: "We should return every violation of the schema in a single error.",
+			req: &v1.RunFunctionRequest{
+				Input: resource.MustStructJSON(`{"apiVersion": "example.org/v1", "kind": "Input", "regoin": "us-east-1"}`),
+			},
+			want: want{
+				in:  &unstructured.Unstructured{},
+				err: "invalid function input: [region: Required value, regoin: Forbidden: unknown field]",
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			in := &unstructured.Unstructured{}
+			err := GetValidatedInput(tc.req, s, in)
+
+			if diff := cmp.Diff(tc.want.in, in); diff != "" {
+				t.Errorf("\n%s\nGetValidatedInput(...): -want, +got:\n%s", tc.reason, diff)
+			}
+			got := ""
+			if err != nil {
+				got = err.Error()
+			}
+			if diff := cmp.Diff(tc.want.err, got); diff != "" {
+				t.Errorf("\n%s\nGetValidatedInput(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
 func TestGetObservedCompositeResource(t *testing.T) {
 	type want struct {
 		oxr *resource.Composite
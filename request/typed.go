@@ -0,0 +1,203 @@
+/*
+Copyright 2026 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package request
+
+import (
+	"maps"
+	"slices"
+
+	"google.golang.org/protobuf/types/known/structpb"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/crossplane/crossplane-runtime/v2/pkg/fieldpath"
+
+	"github.com/crossplane/function-sdk-go/errors"
+	"github.com/crossplane/function-sdk-go/internal/object"
+	v1 "github.com/crossplane/function-sdk-go/proto/v1"
+	"github.com/crossplane/function-sdk-go/resource"
+)
+
+// GetRequiredResourcesAs gets the resources Crossplane resolved for the named
+// requirement from the supplied request, decoding each into a new object of
+// type T. T must be a pointer to a struct, for example *corev1.ConfigMap.
+// Resources are decoded like resource.AsObject, so decoding fails if a resource
+// has fields T doesn't know about. The bool return value indicates whether
+// Crossplane has resolved the requirement.
+func GetRequiredResourcesAs[T runtime.Object](req *v1.RunFunctionRequest, name string) ([]T, bool, error) {
+	rrs, ok := req.GetRequiredResources()[name]
+	if !ok {
+		return nil, false, nil
+	}
+	out := make([]T, 0, len(rrs.GetItems()))
+	for i, r := range rrs.GetItems() {
+		o, err := decodeAs[T](r.GetResource())
+		if err != nil {
+			return nil, true, errors.Wrapf(err, "cannot decode required resource %d of requirement %q", i, name)
+		}
+		out = append(out, o)
+	}
+	return out, true, nil
+}
+
+// GetRequiredResourceAs gets the single resource Crossplane resolved for the
+// named requirement from the supplied request, decoded into a new object of
+// type T. It's useful for requirements that match a resource by name. It
+// returns an error if Crossplane resolved the requirement, but it matched no
+// resources or more than one resource. The bool return value indicates whether
+// Crossplane has resolved the requirement. See GetRequiredResourcesAs.
+func GetRequiredResourceAs[T runtime.Object](req *v1.RunFunctionRequest, name string) (T, bool, error) {
+	var zero T
+	rs, ok, err := GetRequiredResourcesAs[T](req, name)
+	if !ok || err != nil {
+		return zero, ok, err
+	}
+	switch len(rs) {
+	case 0:
+		return zero, true, errors.Errorf("requirement %q matched no resources", name)
+	case 1:
+		return rs[0], true, nil
+	default:
+		return zero, true, errors.Errorf("requirement %q matched %d resources, want exactly one", name, len(rs))
+	}
+}
+
+// GetObservedComposedResourceAs gets the named observed composed resource from
+// the supplied request, decoded into a new object of type T. T must be a
+// pointer to a struct, for example a managed resource type from a provider's
+// Go module. Resources are decoded like resource.AsObject, so decoding fails if
+// the resource has fields T doesn't know about. The bool return value
+// indicates whether the request contains the named resource.
+func GetObservedComposedResourceAs[T runtime.Object](req *v1.RunFunctionRequest, name resource.Name) (T, bool, error) {
+	var zero T
+	r, ok := req.GetObserved().GetResources()[string(name)]
+	if !ok {
+		return zero, false, nil
+	}
+	o, err := decodeAs[T](r.GetResource())
+	return o, true, errors.Wrapf(err, "cannot decode observed composed resource %q", name)
+}
+
+// GetDesiredComposedResourceAs gets the named desired composed resource from
+// the supplied request, decoded into a new object of type T. The bool return
+// value indicates whether the request contains the named resource. See
+// GetObservedComposedResourceAs.
+func GetDesiredComposedResourceAs[T runtime.Object](req *v1.RunFunctionRequest, name resource.Name) (T, bool, error) {
+	var zero T
+	r, ok := req.GetDesired().GetResources()[string(name)]
+	if !ok {
+		return zero, false, nil
+	}
+	o, err := decodeAs[T](r.GetResource())
+	return o, true, errors.Wrapf(err, "cannot decode desired composed resource %q", name)
+}
+
+// decodeAs decodes the supplied struct into a new object of type T. If
+// decoding fails the returned error names the field that caused it, if
+// possible.
+func decodeAs[T runtime.Object](s *structpb.Struct) (T, error) {
+	var zero T
+	o, err := object.New[T]()
+	if err != nil {
+		return zero, err
+	}
+	if err := resource.AsObject(s, o); err != nil {
+		if p := invalidField[T](s.AsMap()); len(p) > 0 {
+			return zero, errors.Wrapf(err, "cannot decode field %s", p)
+		}
+		return zero, err
+	}
+	return o, nil
+}
+
+// invalidField returns the path to the field of the supplied object that
+// can't be decoded into type T. The JSON decoder doesn't report where decoding
+// failed, so we find the field by decoding parts of the object. At each level
+// we decode each field alone, and descend into the first that fails. We stop
+// when a field fails to decode even if it's empty, or when none of its
+// children fail alone. This is only done once decoding has already failed.
+func invalidField[T runtime.Object](obj map[string]any) fieldpath.Segments {
+	var path fieldpath.Segments
+	var v any = obj
+	for {
+		seg, child, ok := invalidChild[T](obj, path, v)
+		if !ok {
+			return path
+		}
+		path = append(path, seg)
+		v = child
+	}
+}
+
+// invalidChild returns the first child of v, the value at the supplied path of
+// the supplied object, that can't be decoded alone into type T.
+func invalidChild[T runtime.Object](obj map[string]any, path fieldpath.Segments, v any) (fieldpath.Segment, any, bool) {
+	switch tv := v.(type) {
+	case map[string]any:
+		if len(path) > 0 && !decodes[T](only(obj, path, map[string]any{})) {
+			return fieldpath.Segment{}, nil, false
+		}
+		for _, k := range slices.Sorted(maps.Keys(tv)) {
+			seg := fieldpath.Field(k)
+			if !decodes[T](only(obj, append(slices.Clone(path), seg), tv[k])) {
+				return seg, tv[k], true
+			}
+		}
+	case []any:
+		if !decodes[T](only(obj, path, []any{})) {
+			return fieldpath.Segment{}, nil, false
+		}
+		for i, e := range tv {
+			seg := fieldpath.Segment{Type: fieldpath.SegmentIndex, Index: uint(i)}
+			if !decodes[T](only(obj, append(slices.Clone(path), seg), e)) {
+				return seg, e, true
+			}
+		}
+	}
+	return fieldpath.Segment{}, nil, false
+}
+
+// only returns a copy of v that contains only the supplied path, with the
+// supplied value at the end of it. Array elements are moved to index zero.
+func only(v any, path fieldpath.Segments, leaf any) any {
+	if len(path) == 0 {
+		return leaf
+	}
+	switch tv := v.(type) {
+	case map[string]any:
+		return map[string]any{path[0].Field: only(tv[path[0].Field], path[1:], leaf)}
+	case []any:
+		return []any{only(tv[path[0].Index], path[1:], leaf)}
+	}
+	return leaf
+}
+
+// decodes returns true if the supplied value decodes into type T.
+func decodes[T runtime.Object](v any) bool {
+	m, ok := v.(map[string]any)
+	if !ok {
+		return false
+	}
+	s, err := structpb.NewStruct(m)
+	if err != nil {
+		return false
+	}
+	o, err := object.New[T]()
+	if err != nil {
+		return false
+	}
+	return resource.AsObject(s, o) == nil
+}
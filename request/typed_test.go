@@ -0,0 +1,351 @@
+/*
+Copyright 2026 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package request
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	v1 "github.com/crossplane/function-sdk-go/proto/v1"
+	"github.com/crossplane/function-sdk-go/resource"
+)
+
+func configMaps(items ...string) *v1.Resources {
+	rs := &v1.Resources{}
+	for _, i := range items {
+		rs.Items = append(rs.Items, &v1.Resource{Resource: resource.MustStructJSON(i)})
+	}
+	return rs
+}
+
+func TestGetRequiredResourcesAs(t *testing.T) {
+	type want struct {
+		resources []*corev1.ConfigMap
+		ok        bool
+		err       error
+	}
+
+	cases := map[string]struct {
+		reason string
+		req    *v1.RunFunctionRequest
+		name   string
+		want   want
+	}{
+		"NotResolved": {
+			reason: "If Crossplane hasn't resolved the requirement we should return nil and false.",
+			req:    &v1.RunFunctionRequest{},
+			name:   "cms",
+			want:   want{ok: false},
+		},
+		"NoMatches": {
+			reason: "If the requirement matched no resources we should return an empty slice and true.",
+			req: &v1.RunFunctionRequest{
+				RequiredResources: map[string]*v1.Resources{"cms": {}},
+			},
+			name: "cms",
+			want: want{resources: []*corev1.ConfigMap{}, ok: true},
+		},
+		"Decoded": {
+			reason: "We should decode each required resource into the supplied type.",
+			req: &v1.RunFunctionRequest{
+				RequiredResources: map[string]*v1.Resources{
+					"cms": configMaps(
+						`{"apiVersion":"v1","kind":"ConfigMap","metadata":{"name":"a"},"data":{"k":"a"}}`,
+						`{"apiVersion":"v1","kind":"ConfigMap","metadata":{"name":"b"},"data":{"k":"b"}}`,
+					),
+				},
+			},
+			name: "cms",
+			want: want{
+				resources: []*corev1.ConfigMap{
+					{
+						TypeMeta:   metav1.TypeMeta{APIVersion: "v1", Kind: "ConfigMap"},
+						ObjectMeta: metav1.ObjectMeta{Name: "a"},
+						Data:       map[string]string{"k": "a"},
+					},
+					{
+						TypeMeta:   metav1.TypeMeta{APIVersion: "v1", Kind: "ConfigMap"},
+						ObjectMeta: metav1.ObjectMeta{Name: "b"},
+						Data:       map[string]string{"k": "b"},
+					},
+				},
+				ok: true,
+			},
+		},
+		"UnknownField": {
+			reason: "We should return an error if a required resource has a field the supplied type doesn't know about.",
+			req: &v1.RunFunctionRequest{
+				RequiredResources: map[string]*v1.Resources{
+					"cms": configMaps(`{"apiVersion":"v1","kind":"ConfigMap","spec":{}}`),
+				},
+			},
+			name: "cms",
+			want: want{ok: true, err: cmpopts.AnyError},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			resources, ok, err := GetRequiredResourcesAs[*corev1.ConfigMap](tc.req, tc.name)
+
+			if diff := cmp.Diff(tc.want.resources, resources); diff != "" {
+				t.Errorf("\n%s\nGetRequiredResourcesAs(...): -want, +got:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.ok, ok); diff != "" {
+				t.Errorf("\n%s\nGetRequiredResourcesAs(...) ok: -want, +got:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.err, err, cmpopts.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nGetRequiredResourcesAs(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestGetRequiredResourceAs(t *testing.T) {
+	type want struct {
+		resource *corev1.ConfigMap
+		ok       bool
+		err      error
+	}
+
+	cases := map[string]struct {
+		reason string
+		req    *v1.RunFunctionRequest
+		want   want
+	}{
+		"NotResolved": {
+			reason: "If Crossplane hasn't resolved the requirement we should return nil and false.",
+			req:    &v1.RunFunctionRequest{},
+			want:   want{ok: false},
+		},
+		"NoMatches": {
+			reason: "We should return an error if the requirement matched no resources.",
+			req: &v1.RunFunctionRequest{
+				RequiredResources: map[string]*v1.Resources{"cm": {}},
+			},
+			want: want{ok: true, err: cmpopts.AnyError},
+		},
+		"OneMatch": {
+			reason: "We should return the resource if the requirement matched exactly one resource.",
+			req: &v1.RunFunctionRequest{
+				RequiredResources: map[string]*v1.Resources{
+					"cm": configMaps(`{"apiVersion":"v1","kind":"ConfigMap","metadata":{"name":"a"}}`),
+				},
+			},
+			want: want{
+				resource: &corev1.ConfigMap{
+					TypeMeta:   metav1.TypeMeta{APIVersion: "v1", Kind: "ConfigMap"},
+					ObjectMeta: metav1.ObjectMeta{Name: "a"},
+				},
+				ok: true,
+			},
+		},
+		"ManyMatches": {
+			reason: "We should return an error if the requirement matched more than one resource.",
+			req: &v1.RunFunctionRequest{
+				RequiredResources: map[string]*v1.Resources{
+					"cm": configMaps(
+						`{"apiVersion":"v1","kind":"ConfigMap","metadata":{"name":"a"}}`,
+						`{"apiVersion":"v1","kind":"ConfigMap","metadata":{"name":"b"}}`,
+					),
+				},
+			},
+			want: want{ok: true, err: cmpopts.AnyError},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			r, ok, err := GetRequiredResourceAs[*corev1.ConfigMap](tc.req, "cm")
+
+			if diff := cmp.Diff(tc.want.resource, r); diff != "" {
+				t.Errorf("\n%s\nGetRequiredResourceAs(...): -want, +got:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.ok, ok); diff != "" {
+				t.Errorf("\n%s\nGetRequiredResourceAs(...) ok: -want, +got:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.err, err, cmpopts.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nGetRequiredResourceAs(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestGetObservedComposedResourceAs(t *testing.T) {
+	type want struct {
+		resource *corev1.ConfigMap
+		ok       bool
+		err      error
+	}
+
+	cases := map[string]struct {
+		reason string
+		req    *v1.RunFunctionRequest
+		want   want
+	}{
+		"NotObserved": {
+			reason: "If the named composed resource isn't observed we should return nil and false.",
+			req:    &v1.RunFunctionRequest{},
+			want:   want{ok: false},
+		},
+		"Decoded": {
+			reason: "We should decode the named composed resource into the supplied type.",
+			req: &v1.RunFunctionRequest{
+				Observed: &v1.State{
+					Resources: map[string]*v1.Resource{
+						"cm": {Resource: resource.MustStructJSON(`{"apiVersion":"v1","kind":"ConfigMap","data":{"k":"v"}}`)},
+					},
+				},
+			},
+			want: want{
+				resource: &corev1.ConfigMap{
+					TypeMeta: metav1.TypeMeta{APIVersion: "v1", Kind: "ConfigMap"},
+					Data:     map[string]string{"k": "v"},
+				},
+				ok: true,
+			},
+		},
+		"InvalidField": {
+			reason: "We should return an error if the named composed resource can't be decoded.",
+			req: &v1.RunFunctionRequest{
+				Observed: &v1.State{
+					Resources: map[string]*v1.Resource{
+						"cm": {Resource: resource.MustStructJSON(`{"apiVersion":"v1","kind":"ConfigMap","data":{"k":1}}`)},
+					},
+				},
+			},
+			want: want{ok: true, err: cmpopts.AnyError},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			r, ok, err := GetObservedComposedResourceAs[*corev1.ConfigMap](tc.req, "cm")
+
+			if diff := cmp.Diff(tc.want.resource, r); diff != "" {
+				t.Errorf("\n%s\nGetObservedComposedResourceAs(...): -want, +got:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.ok, ok); diff != "" {
+				t.Errorf("\n%s\nGetObservedComposedResourceAs(...) ok: -want, +got:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.err, err, cmpopts.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nGetObservedComposedResourceAs(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestGetDesiredComposedResourceAs(t *testing.T) {
+	type want struct {
+		resource *corev1.ConfigMap
+		ok       bool
+		err      error
+	}
+
+	cases := map[string]struct {
+		reason string
+		req    *v1.RunFunctionRequest
+		want   want
+	}{
+		"NotDesired": {
+			reason: "If the named composed resource isn't desired we should return nil and false.",
+			req:    &v1.RunFunctionRequest{},
+			want:   want{ok: false},
+		},
+		"Decoded": {
+			reason: "We should decode the named composed resource into the supplied type.",
+			req: &v1.RunFunctionRequest{
+				Desired: &v1.State{
+					Resources: map[string]*v1.Resource{
+						"cm": {Resource: resource.MustStructJSON(`{"apiVersion":"v1","kind":"ConfigMap","data":{"k":"v"}}`)},
+					},
+				},
+			},
+			want: want{
+				resource: &corev1.ConfigMap{
+					TypeMeta: metav1.TypeMeta{APIVersion: "v1", Kind: "ConfigMap"},
+					Data:     map[string]string{"k": "v"},
+				},
+				ok: true,
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			r, ok, err := GetDesiredComposedResourceAs[*corev1.ConfigMap](tc.req, "cm")
+
+			if diff := cmp.Diff(tc.want.resource, r); diff != "" {
+				t.Errorf("\n%s\nGetDesiredComposedResourceAs(...): -want, +got:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.ok, ok); diff != "" {
+				t.Errorf("\n%s\nGetDesiredComposedResourceAs(...) ok: -want, +got:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.err, err, cmpopts.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nGetDesiredComposedResourceAs(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestInvalidField(t *testing.T) {
+	cases := map[string]struct {
+		reason string
+		obj    string
+		want   string
+	}{
+		"Valid": {
+			reason: "We should return an empty path if the object decodes.",
+			obj:    `{"apiVersion":"v1","kind":"ConfigMap","data":{"k":"v"}}`,
+			want:   "",
+		},
+		"UnknownField": {
+			reason: "We should return the path to a field the type doesn't know about.",
+			obj:    `{"apiVersion":"v1","kind":"ConfigMap","metadata":{"name":"a","cool":true}}`,
+			want:   "metadata.cool",
+		},
+		"WrongMapValueType": {
+			reason: "We should return the path to a map value of the wrong type.",
+			obj:    `{"apiVersion":"v1","kind":"ConfigMap","data":{"a":"b","k":1}}`,
+			want:   "data.k",
+		},
+		"WrongType": {
+			reason: "We should return the path to a field of the wrong type, not to its children.",
+			obj:    `{"apiVersion":"v1","kind":"ConfigMap","data":["a"]}`,
+			want:   "data",
+		},
+		"ArrayElement": {
+			reason: "We should return the path to an invalid array element.",
+			obj:    `{"apiVersion":"v1","kind":"ConfigMap","metadata":{"finalizers":["a",1]}}`,
+			want:   "metadata.finalizers[1]",
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := invalidField[*corev1.ConfigMap](resource.MustStructJSON(tc.obj).AsMap())
+
+			if diff := cmp.Diff(tc.want, got.String()); diff != "" {
+				t.Errorf("\n%s\ninvalidField(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
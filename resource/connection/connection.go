@@ -0,0 +1,176 @@
+/*
+Copyright 2026 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package connection extracts a composite resource's connection details from
+// its observed composed resources, using the same connection detail types as
+// a patch-and-transform Composition.
+package connection
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"slices"
+
+	"github.com/crossplane/crossplane-runtime/v2/pkg/fieldpath"
+
+	"github.com/crossplane/function-sdk-go/errors"
+	v1 "github.com/crossplane/function-sdk-go/proto/v1"
+	"github.com/crossplane/function-sdk-go/request"
+	"github.com/crossplane/function-sdk-go/resource"
+)
+
+// A NotFoundError is returned when the source of a connection detail doesn't
+// exist. This is often temporary; for example a composed resource's connection
+// secret may not have been written yet.
+type NotFoundError struct {
+	error
+}
+
+// IsNotFound returns true if the supplied error indicates the source of a
+// connection detail doesn't exist.
+func IsNotFound(err error) bool {
+	e := &NotFoundError{}
+	return errors.As(err, &e)
+}
+
+// A Detail extracts a connection detail from the supplied observed composed
+// resource. It returns the connection detail's name and value.
+type Detail func(ocd resource.ObservedComposed) (string, []byte, error)
+
+// Details to extract from each composed resource, by name.
+type Details map[resource.Name][]Detail
+
+// FromConnectionSecretKey returns a Detail with the supplied name, whose value
+// is the supplied key of the composed resource's connection details.
+func FromConnectionSecretKey(name, key string) Detail {
+	return func(ocd resource.ObservedComposed) (string, []byte, error) {
+		v, ok := ocd.ConnectionDetails[key]
+		if !ok {
+			return name, nil, &NotFoundError{errors.Errorf("connection secret key %q not found", key)}
+		}
+		return name, v, nil
+	}
+}
+
+// FromFieldPath returns a Detail with the supplied name, whose value is the
+// value of the supplied field path of the composed resource. String values are
+// used as is. Other values are encoded as JSON.
+func FromFieldPath(name, path string) Detail {
+	return func(ocd resource.ObservedComposed) (string, []byte, error) {
+		v, err := ocd.Resource.GetValue(path)
+		if fieldpath.IsNotFound(err) {
+			return name, nil, &NotFoundError{errors.Errorf("field path %q not found", path)}
+		}
+		if err != nil {
+			return name, nil, errors.Wrapf(err, "cannot get field path %q", path)
+		}
+		if s, ok := v.(string); ok {
+			return name, []byte(s), nil
+		}
+		b, err := json.Marshal(v)
+		return name, b, errors.Wrapf(err, "cannot encode field path %q as JSON", path)
+	}
+}
+
+// FromBase64FieldPath returns a Detail with the supplied name, whose value is
+// the base64 decoded value of the supplied field path of the composed
+// resource. The field path must be a string.
+func FromBase64FieldPath(name, path string) Detail {
+	return func(ocd resource.ObservedComposed) (string, []byte, error) {
+		s, err := ocd.Resource.GetString(path)
+		if fieldpath.IsNotFound(err) {
+			return name, nil, &NotFoundError{errors.Errorf("field path %q not found", path)}
+		}
+		if err != nil {
+			return name, nil, errors.Wrapf(err, "cannot get field path %q", path)
+		}
+		b, err := base64.StdEncoding.DecodeString(s)
+		return name, b, errors.Wrapf(err, "cannot base64 decode field path %q", path)
+	}
+}
+
+// FromValue returns a Detail with the supplied name and value.
+func FromValue(name, value string) Detail {
+	return func(_ resource.ObservedComposed) (string, []byte, error) {
+		return name, []byte(value), nil
+	}
+}
+
+// Extract the supplied connection details from the supplied observed composed
+// resource. If the source of one or more connection details doesn't exist it
+// returns the connection details it could extract, and an error for which
+// IsNotFound returns true.
+func Extract(ocd resource.ObservedComposed, ds ...Detail) (resource.ConnectionDetails, error) {
+	out := resource.ConnectionDetails{}
+	missing := make([]error, 0)
+	for _, d := range ds {
+		name, v, err := d(ocd)
+		if IsNotFound(err) {
+			missing = append(missing, errors.Wrapf(err, "cannot extract connection detail %q", name))
+			continue
+		}
+		if err != nil {
+			return nil, errors.Wrapf(err, "cannot extract connection detail %q", name)
+		}
+		out[name] = v
+	}
+	if err := errors.Join(missing...); err != nil {
+		return out, err
+	}
+	return out, nil
+}
+
+// ExtractComposed extracts the supplied connection details from the observed
+// composed resources in the supplied request, and returns them as the
+// composite resource's connection details. Composed resources that haven't
+// been observed yet are skipped. If the source of one or more connection
+// details doesn't exist it returns the connection details it could extract,
+// and an error for which IsNotFound returns true.
+func ExtractComposed(req *v1.RunFunctionRequest, details Details) (resource.ConnectionDetails, error) {
+	ocds, err := request.GetObservedComposedResources(req)
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot get observed composed resources")
+	}
+
+	names := make([]resource.Name, 0, len(details))
+	for name := range details {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+
+	out := resource.ConnectionDetails{}
+	missing := make([]error, 0)
+	for _, name := range names {
+		ocd, ok := ocds[name]
+		if !ok {
+			continue
+		}
+		cd, err := Extract(ocd, details[name]...)
+		if err != nil && !IsNotFound(err) {
+			return nil, errors.Wrapf(err, "cannot extract connection details from composed resource %q", name)
+		}
+		if err != nil {
+			missing = append(missing, errors.Wrapf(err, "cannot extract connection details from composed resource %q", name))
+		}
+		for k, v := range cd {
+			out[k] = v
+		}
+	}
+	if err := errors.Join(missing...); err != nil {
+		return out, err
+	}
+	return out, nil
+}
@@ -0,0 +1,219 @@
+/*
+Copyright 2026 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package connection
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+
+	v1 "github.com/crossplane/function-sdk-go/proto/v1"
+	"github.com/crossplane/function-sdk-go/resource"
+	"github.com/crossplane/function-sdk-go/resource/composed"
+)
+
+func MustObserved(t *testing.T, j string, cd resource.ConnectionDetails) resource.ObservedComposed {
+	t.Helper()
+	ocd := resource.ObservedComposed{Resource: composed.New(), ConnectionDetails: cd}
+	if err := resource.AsObject(resource.MustStructJSON(j), ocd.Resource); err != nil {
+		t.Fatalf("resource.AsObject(...): %v", err)
+	}
+	return ocd
+}
+
+func TestExtract(t *testing.T) {
+	ocd := MustObserved(t, `{
+		"status": {
+			"atProvider": {
+				"endpoint": "db.example.org",
+				"port": 5432,
+				"tags": {"env": "prod"},
+				"caData": "Y29vbA==",
+				"notBase64": "!"
+			}
+		}
+	}`, resource.ConnectionDetails{"password": []byte("secret")})
+
+	type want struct {
+		cd       resource.ConnectionDetails
+		err      error
+		notFound bool
+	}
+
+	cases := map[string]struct {
+		reason string
+		ds     []Detail
+		want   want
+	}{
+		"Extracted": {
+			reason: "We should extract connection details from connection secret keys, field paths, and values.",
+			ds: []Detail{
+				FromConnectionSecretKey("password", "password"),
+				FromFieldPath("endpoint", "status.atProvider.endpoint"),
+				FromFieldPath("port", "status.atProvider.port"),
+				FromFieldPath("tags", "status.atProvider.tags"),
+				FromBase64FieldPath("ca", "status.atProvider.caData"),
+				FromValue("username", "admin"),
+			},
+			want: want{
+				cd: resource.ConnectionDetails{
+					"password": []byte("secret"),
+					"endpoint": []byte("db.example.org"),
+					"port":     []byte("5432"),
+					"tags":     []byte(`{"env":"prod"}`),
+					"ca":       []byte("cool"),
+					"username": []byte("admin"),
+				},
+			},
+		},
+		"ConnectionSecretKeyNotFound": {
+			reason: "We should return a NotFoundError if a connection secret key doesn't exist.",
+			ds:     []Detail{FromConnectionSecretKey("user", "username")},
+			want:   want{cd: resource.ConnectionDetails{}, err: cmpopts.AnyError, notFound: true},
+		},
+		"FieldPathNotFound": {
+			reason: "We should return a NotFoundError if a field path doesn't exist.",
+			ds:     []Detail{FromFieldPath("address", "status.atProvider.address")},
+			want:   want{cd: resource.ConnectionDetails{}, err: cmpopts.AnyError, notFound: true},
+		},
+		"Base64FieldPathNotFound": {
+			reason: "We should return a NotFoundError if a base64 field path doesn't exist.",
+			ds:     []Detail{FromBase64FieldPath("ca", "status.atProvider.ca")},
+			want:   want{cd: resource.ConnectionDetails{}, err: cmpopts.AnyError, notFound: true},
+		},
+		"PartiallyNotFound": {
+			reason: "We should return the connection details we could extract, and a NotFoundError, if the source of some connection details doesn't exist.",
+			ds: []Detail{
+				FromFieldPath("endpoint", "status.atProvider.endpoint"),
+				FromFieldPath("address", "status.atProvider.address"),
+				FromConnectionSecretKey("user", "username"),
+			},
+			want: want{
+				cd:       resource.ConnectionDetails{"endpoint": []byte("db.example.org")},
+				err:      cmpopts.AnyError,
+				notFound: true,
+			},
+		},
+		"NotBase64": {
+			reason: "We should return an error if a base64 field path isn't valid base64.",
+			ds:     []Detail{FromBase64FieldPath("ca", "status.atProvider.notBase64")},
+			want:   want{err: cmpopts.AnyError},
+		},
+		"Base64NotString": {
+			reason: "We should return an error if a base64 field path isn't a string.",
+			ds:     []Detail{FromBase64FieldPath("port", "status.atProvider.port")},
+			want:   want{err: cmpopts.AnyError},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			cd, err := Extract(ocd, tc.ds...)
+
+			if diff := cmp.Diff(tc.want.cd, cd); diff != "" {
+				t.Errorf("\n%s\nExtract(...): -want, +got:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.err, err, cmpopts.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nExtract(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.notFound, IsNotFound(err)); diff != "" {
+				t.Errorf("\n%s\nIsNotFound(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestExtractComposed(t *testing.T) {
+	req := &v1.RunFunctionRequest{
+		Observed: &v1.State{
+			Resources: map[string]*v1.Resource{
+				"db": {
+					Resource:          resource.MustStructJSON(`{"status": {"atProvider": {"endpoint": "db.example.org"}}}`),
+					ConnectionDetails: map[string][]byte{"password": []byte("secret")},
+				},
+				"cache": {
+					Resource: resource.MustStructJSON(`{"status": {}}`),
+				},
+			},
+		},
+	}
+
+	type want struct {
+		cd       resource.ConnectionDetails
+		err      error
+		notFound bool
+	}
+
+	cases := map[string]struct {
+		reason  string
+		details Details
+		want    want
+	}{
+		"Extracted": {
+			reason: "We should extract connection details from each observed composed resource, skipping resources that haven't been observed.",
+			details: Details{
+				"db": {
+					FromConnectionSecretKey("password", "password"),
+					FromFieldPath("endpoint", "status.atProvider.endpoint"),
+				},
+				"bucket": {FromValue("bucket", "cool")},
+			},
+			want: want{
+				cd: resource.ConnectionDetails{
+					"password": []byte("secret"),
+					"endpoint": []byte("db.example.org"),
+				},
+			},
+		},
+		"NotFound": {
+			reason: "We should return a NotFoundError if the source of a connection detail doesn't exist.",
+			details: Details{
+				"cache": {FromFieldPath("endpoint", "status.atProvider.endpoint")},
+			},
+			want: want{cd: resource.ConnectionDetails{}, err: cmpopts.AnyError, notFound: true},
+		},
+		"PartiallyNotFound": {
+			reason: "We should return the connection details we could extract, and a NotFoundError, if the source of some connection details doesn't exist.",
+			details: Details{
+				"db":    {FromFieldPath("endpoint", "status.atProvider.endpoint")},
+				"cache": {FromFieldPath("cache", "status.atProvider.endpoint")},
+			},
+			want: want{
+				cd:       resource.ConnectionDetails{"endpoint": []byte("db.example.org")},
+				err:      cmpopts.AnyError,
+				notFound: true,
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			cd, err := ExtractComposed(req, tc.details)
+
+			if diff := cmp.Diff(tc.want.cd, cd); diff != "" {
+				t.Errorf("\n%s\nExtractComposed(...): -want, +got:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.err, err, cmpopts.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nExtractComposed(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.notFound, IsNotFound(err)); diff != "" {
+				t.Errorf("\n%s\nIsNotFound(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
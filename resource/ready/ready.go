@@ -0,0 +1,173 @@
+/*
+Copyright 2026 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package ready determines whether composed resources are ready, using the
+// same readiness checks as a patch-and-transform Composition.
+package ready
+
+import (
+	"slices"
+
+	xpv2 "github.com/crossplane/crossplane/apis/v2/core/v2"
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/crossplane/crossplane-runtime/v2/pkg/fieldpath"
+
+	"github.com/crossplane/function-sdk-go/errors"
+	v1 "github.com/crossplane/function-sdk-go/proto/v1"
+	"github.com/crossplane/function-sdk-go/request"
+	"github.com/crossplane/function-sdk-go/resource"
+	"github.com/crossplane/function-sdk-go/resource/composed"
+)
+
+// A Check determines whether the supplied observed composed resource is ready.
+type Check func(cd *composed.Unstructured) (bool, error)
+
+// Checks of each composed resource, by name.
+type Checks map[resource.Name][]Check
+
+// NonEmpty returns a Check that passes when the supplied field path exists and
+// has a value.
+func NonEmpty(path string) Check {
+	return func(cd *composed.Unstructured) (bool, error) {
+		if _, err := cd.GetValue(path); err != nil {
+			return false, ignoreNotFound(err)
+		}
+		return true, nil
+	}
+}
+
+// MatchString returns a Check that passes when the supplied field path is the
+// supplied string.
+func MatchString(path, want string) Check {
+	return func(cd *composed.Unstructured) (bool, error) {
+		got, err := cd.GetString(path)
+		if err != nil {
+			return false, ignoreNotFound(err)
+		}
+		return got == want, nil
+	}
+}
+
+// MatchInteger returns a Check that passes when the supplied field path is the
+// supplied integer.
+func MatchInteger(path string, want int64) Check {
+	return func(cd *composed.Unstructured) (bool, error) {
+		got, err := cd.GetInteger(path)
+		if err != nil {
+			return false, ignoreNotFound(err)
+		}
+		return got == want, nil
+	}
+}
+
+// MatchTrue returns a Check that passes when the supplied field path is true.
+func MatchTrue(path string) Check {
+	return matchBool(path, true)
+}
+
+// MatchFalse returns a Check that passes when the supplied field path is false.
+func MatchFalse(path string) Check {
+	return matchBool(path, false)
+}
+
+func matchBool(path string, want bool) Check {
+	return func(cd *composed.Unstructured) (bool, error) {
+		got, err := cd.GetBool(path)
+		if err != nil {
+			return false, ignoreNotFound(err)
+		}
+		return got == want, nil
+	}
+}
+
+// MatchCondition returns a Check that passes when the supplied type of status
+// condition has the supplied status.
+func MatchCondition(ct xpv2.ConditionType, status corev1.ConditionStatus) Check {
+	return func(cd *composed.Unstructured) (bool, error) {
+		return cd.GetCondition(ct).Status == status, nil
+	}
+}
+
+// None returns a Check that always passes. Use it for composed resources that
+// are ready as soon as they exist, such as those without a status.
+func None() Check {
+	return func(_ *composed.Unstructured) (bool, error) {
+		return true, nil
+	}
+}
+
+// IsReady returns true if the supplied observed composed resource passes all
+// of the supplied checks. When no checks are supplied the resource must have a
+// Ready condition with status True, like Crossplane's default readiness check.
+// A field path that doesn't exist fails its check without returning an error.
+func IsReady(cd *composed.Unstructured, checks ...Check) (bool, error) {
+	if len(checks) == 0 {
+		checks = []Check{MatchCondition(xpv2.TypeReady, corev1.ConditionTrue)}
+	}
+	for i, c := range checks {
+		ready, err := c(cd)
+		if err != nil {
+			return false, errors.Wrapf(err, "readiness check %d failed", i)
+		}
+		if !ready {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// SetDesiredReady determines whether each of the supplied desired composed
+// resources is ready, by running the supplied checks against the observed
+// composed resource of the same name in the supplied request. It sets each
+// desired resource's Ready to ReadyTrue or ReadyFalse accordingly. Resources
+// without checks must have a Ready condition with status True. Desired
+// resources that haven't been observed yet are left unchanged.
+func SetDesiredReady(req *v1.RunFunctionRequest, dcds map[resource.Name]*resource.DesiredComposed, checks Checks) error {
+	ocds, err := request.GetObservedComposedResources(req)
+	if err != nil {
+		return errors.Wrap(err, "cannot get observed composed resources")
+	}
+
+	names := make([]resource.Name, 0, len(dcds))
+	for name := range dcds {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+
+	for _, name := range names {
+		ocd, ok := ocds[name]
+		if !ok {
+			continue
+		}
+		ready, err := IsReady(ocd.Resource, checks[name]...)
+		if err != nil {
+			return errors.Wrapf(err, "cannot determine whether composed resource %q is ready", name)
+		}
+		dcds[name].Ready = resource.ReadyFalse
+		if ready {
+			dcds[name].Ready = resource.ReadyTrue
+		}
+	}
+	return nil
+}
+
+func ignoreNotFound(err error) error {
+	if fieldpath.IsNotFound(err) {
+		return nil
+	}
+	return err
+}
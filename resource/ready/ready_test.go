@@ -0,0 +1,225 @@
+/*
+Copyright 2026 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ready
+
+import (
+	"testing"
+
+	xpv2 "github.com/crossplane/crossplane/apis/v2/core/v2"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	corev1 "k8s.io/api/core/v1"
+
+	v1 "github.com/crossplane/function-sdk-go/proto/v1"
+	"github.com/crossplane/function-sdk-go/resource"
+	"github.com/crossplane/function-sdk-go/resource/composed"
+)
+
+func MustComposed(t *testing.T, j string) *composed.Unstructured {
+	t.Helper()
+	cd := composed.New()
+	if err := resource.AsObject(resource.MustStructJSON(j), cd); err != nil {
+		t.Fatalf("resource.AsObject(...): %v", err)
+	}
+	return cd
+}
+
+func TestIsReady(t *testing.T) {
+	type want struct {
+		ready bool
+		err   error
+	}
+
+	cases := map[string]struct {
+		reason string
+		obj    string
+		checks []Check
+		want   want
+	}{
+		"DefaultReady": {
+			reason: "A resource with a Ready condition with status True should be ready when there are no checks.",
+			obj:    `{"status": {"conditions": [{"type": "Ready", "status": "True"}]}}`,
+			want:   want{ready: true},
+		},
+		"DefaultNotReady": {
+			reason: "A resource without a Ready condition should not be ready when there are no checks.",
+			obj:    `{"status": {"atProvider": {"id": "cool"}}}`,
+			want:   want{ready: false},
+		},
+		"NonEmpty": {
+			reason: "A NonEmpty check should pass when the field exists.",
+			obj:    `{"status": {"atProvider": {"id": "cool"}}}`,
+			checks: []Check{NonEmpty("status.atProvider.id")},
+			want:   want{ready: true},
+		},
+		"NonEmptyMissing": {
+			reason: "A NonEmpty check should fail when the field doesn't exist.",
+			obj:    `{"status": {}}`,
+			checks: []Check{NonEmpty("status.atProvider.id")},
+			want:   want{ready: false},
+		},
+		"MatchString": {
+			reason: "A MatchString check should pass when the field matches.",
+			obj:    `{"status": {"phase": "Running"}}`,
+			checks: []Check{MatchString("status.phase", "Running")},
+			want:   want{ready: true},
+		},
+		"MatchStringMismatch": {
+			reason: "A MatchString check should fail when the field doesn't match.",
+			obj:    `{"status": {"phase": "Pending"}}`,
+			checks: []Check{MatchString("status.phase", "Running")},
+			want:   want{ready: false},
+		},
+		"MatchStringWrongType": {
+			reason: "A MatchString check should return an error when the field isn't a string.",
+			obj:    `{"status": {"phase": 42}}`,
+			checks: []Check{MatchString("status.phase", "Running")},
+			want:   want{ready: false, err: cmpopts.AnyError},
+		},
+		"MatchInteger": {
+			reason: "A MatchInteger check should pass when the field matches, even though numbers from a struct are floats.",
+			obj:    `{"status": {"replicas": 3}}`,
+			checks: []Check{MatchInteger("status.replicas", 3)},
+			want:   want{ready: true},
+		},
+		"MatchIntegerMissing": {
+			reason: "A MatchInteger check should fail when the field doesn't exist.",
+			obj:    `{"status": {}}`,
+			checks: []Check{MatchInteger("status.replicas", 3)},
+			want:   want{ready: false},
+		},
+		"MatchTrue": {
+			reason: "A MatchTrue check should pass when the field is true.",
+			obj:    `{"status": {"available": true}}`,
+			checks: []Check{MatchTrue("status.available")},
+			want:   want{ready: true},
+		},
+		"MatchFalse": {
+			reason: "A MatchFalse check should pass when the field is false.",
+			obj:    `{"status": {"degraded": false}}`,
+			checks: []Check{MatchFalse("status.degraded")},
+			want:   want{ready: true},
+		},
+		"MatchFalseMissing": {
+			reason: "A MatchFalse check should fail when the field doesn't exist.",
+			obj:    `{"status": {}}`,
+			checks: []Check{MatchFalse("status.degraded")},
+			want:   want{ready: false},
+		},
+		"MatchCondition": {
+			reason: "A MatchCondition check should pass when the condition has the status.",
+			obj:    `{"status": {"conditions": [{"type": "Synced", "status": "True"}]}}`,
+			checks: []Check{MatchCondition(xpv2.TypeSynced, corev1.ConditionTrue)},
+			want:   want{ready: true},
+		},
+		"None": {
+			reason: "A None check should always pass.",
+			obj:    `{}`,
+			checks: []Check{None()},
+			want:   want{ready: true},
+		},
+		"AllChecksMustPass": {
+			reason: "A resource should only be ready if it passes every check.",
+			obj:    `{"status": {"phase": "Running", "available": false}}`,
+			checks: []Check{MatchString("status.phase", "Running"), MatchTrue("status.available")},
+			want:   want{ready: false},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			ready, err := IsReady(MustComposed(t, tc.obj), tc.checks...)
+
+			if diff := cmp.Diff(tc.want.ready, ready); diff != "" {
+				t.Errorf("\n%s\nIsReady(...): -want ready, +got ready:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.err, err, cmpopts.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nIsReady(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestSetDesiredReady(t *testing.T) {
+	req := &v1.RunFunctionRequest{
+		Observed: &v1.State{
+			Resources: map[string]*v1.Resource{
+				"ready-bucket":   {Resource: resource.MustStructJSON(`{"status": {"conditions": [{"type": "Ready", "status": "True"}]}}`)},
+				"unready-bucket": {Resource: resource.MustStructJSON(`{"status": {"conditions": [{"type": "Ready", "status": "False"}]}}`)},
+				"config":         {Resource: resource.MustStructJSON(`{"data": {"a": "b"}}`)},
+				"bad":            {Resource: resource.MustStructJSON(`{"status": {"phase": 42}}`)},
+			},
+		},
+	}
+
+	type want struct {
+		ready map[resource.Name]resource.Ready
+		err   error
+	}
+
+	cases := map[string]struct {
+		reason string
+		dcds   []resource.Name
+		checks Checks
+		want   want
+	}{
+		"SetReady": {
+			reason: "We should set each observed desired resource's readiness, using the default check for resources without checks.",
+			dcds:   []resource.Name{"ready-bucket", "unready-bucket", "config", "new-bucket"},
+			checks: Checks{"config": {None()}},
+			want: want{
+				ready: map[resource.Name]resource.Ready{
+					"ready-bucket":   resource.ReadyTrue,
+					"unready-bucket": resource.ReadyFalse,
+					"config":         resource.ReadyTrue,
+					"new-bucket":     resource.ReadyUnspecified,
+				},
+			},
+		},
+		"CheckError": {
+			reason: "We should return an error if a check returns an error.",
+			dcds:   []resource.Name{"bad"},
+			checks: Checks{"bad": {MatchString("status.phase", "Running")}},
+			want: want{
+				ready: map[resource.Name]resource.Ready{"bad": resource.ReadyUnspecified},
+				err:   cmpopts.AnyError,
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			dcds := map[resource.Name]*resource.DesiredComposed{}
+			for _, name := range tc.dcds {
+				dcds[name] = &resource.DesiredComposed{Resource: composed.New(), Ready: resource.ReadyUnspecified}
+			}
+
+			err := SetDesiredReady(req, dcds, tc.checks)
+
+			got := map[resource.Name]resource.Ready{}
+			for name, dcd := range dcds {
+				got[name] = dcd.Ready
+			}
+			if diff := cmp.Diff(tc.want.ready, got); diff != "" {
+				t.Errorf("\n%s\nSetDesiredReady(...): -want ready, +got ready:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.err, err, cmpopts.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nSetDesiredReady(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
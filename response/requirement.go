@@ -0,0 +1,115 @@
+/*
+Copyright 2026 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package response
+
+import (
+	"maps"
+
+	"github.com/crossplane/function-sdk-go/errors"
+	v1 "github.com/crossplane/function-sdk-go/proto/v1"
+	"github.com/crossplane/function-sdk-go/request"
+)
+
+// A ResourceRequirement is a requirement for resources that has been added to a
+// response. Use its methods to further customize the requirement.
+type ResourceRequirement struct {
+	selector *v1.ResourceSelector
+}
+
+// RequireResource adds a requirement for the resource with the supplied
+// apiVersion, kind, and name to the response. This tells Crossplane to fetch
+// the resource and include it in the next request's required_resources field,
+// keyed by the supplied requirement name. Use request.GetRequiredResource to
+// retrieve it.
+//
+// The resource is cluster scoped unless InNamespace is used. Calling
+// RequireResource again with the same requirement name replaces the
+// requirement.
+func RequireResource(rsp *v1.RunFunctionResponse, name, apiVersion, kind, resourceName string) *ResourceRequirement {
+	s := &v1.ResourceSelector{
+		ApiVersion: apiVersion,
+		Kind:       kind,
+		Match:      &v1.ResourceSelector_MatchName{MatchName: resourceName},
+	}
+	setResourceRequirement(rsp, name, s)
+	return &ResourceRequirement{selector: s}
+}
+
+// RequireResources adds a requirement for all resources with the supplied
+// apiVersion and kind that have the supplied labels to the response. This
+// tells Crossplane to fetch the resources and include them in the next
+// request's required_resources field, keyed by the supplied requirement name.
+// Use request.GetRequiredResource to retrieve them.
+//
+// Resources are matched across all namespaces unless InNamespace is used.
+// Calling RequireResources again with the same requirement name, apiVersion,
+// and kind merges the supplied labels into the existing requirement, so that
+// only resources with all of the labels match. The existing requirement's
+// namespace is kept unless InNamespace is used. Any other existing requirement
+// with the same name is replaced.
+func RequireResources(rsp *v1.RunFunctionResponse, name, apiVersion, kind string, labels map[string]string) *ResourceRequirement {
+	if s := rsp.GetRequirements().GetResources()[name]; s.GetMatchLabels() != nil && s.GetApiVersion() == apiVersion && s.GetKind() == kind {
+		if s.GetMatchLabels().Labels == nil {
+			s.GetMatchLabels().Labels = make(map[string]string, len(labels))
+		}
+		maps.Copy(s.GetMatchLabels().Labels, labels)
+		return &ResourceRequirement{selector: s}
+	}
+
+	s := &v1.ResourceSelector{
+		ApiVersion: apiVersion,
+		Kind:       kind,
+		Match:      &v1.ResourceSelector_MatchLabels{MatchLabels: &v1.MatchLabels{Labels: maps.Clone(labels)}},
+	}
+	setResourceRequirement(rsp, name, s)
+	return &ResourceRequirement{selector: s}
+}
+
+// InNamespace scopes the requirement to the supplied namespace.
+func (o *ResourceRequirement) InNamespace(namespace string) *ResourceRequirement {
+	o.selector.Namespace = &namespace
+	return o
+}
+
+func setResourceRequirement(rsp *v1.RunFunctionResponse, name string, s *v1.ResourceSelector) {
+	if rsp.GetRequirements() == nil {
+		rsp.Requirements = &v1.Requirements{}
+	}
+	if rsp.Requirements.Resources == nil {
+		rsp.Requirements.Resources = make(map[string]*v1.ResourceSelector)
+	}
+	rsp.Requirements.Resources[name] = s
+}
+
+// WarnIfRequiredResourcesUnsupported adds a warning result to the supplied
+// response if it requires resources, but the supplied request's Crossplane
+// advertises capabilities that don't include required resources. It returns
+// true if it added a warning.
+//
+// Crossplane versions that predate capability advertisement don't advertise
+// any capabilities, so we can't tell whether they support required resources.
+// No warning is added for these versions.
+func WarnIfRequiredResourcesUnsupported(req *v1.RunFunctionRequest, rsp *v1.RunFunctionResponse) bool {
+	if len(rsp.GetRequirements().GetResources()) == 0 {
+		return false
+	}
+	if !request.AdvertisesCapabilities(req) || request.HasCapability(req, v1.Capability_CAPABILITY_REQUIRED_RESOURCES) {
+		return false
+	}
+	Warning(rsp, errors.New("this Function requires resources, but Crossplane doesn't support required resources - the requirements will be ignored"))
+	return true
+}
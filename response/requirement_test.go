@@ -0,0 +1,204 @@
+/*
+Copyright 2026 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package response
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/protobuf/testing/protocmp"
+
+	v1 "github.com/crossplane/function-sdk-go/proto/v1"
+)
+
+func TestRequireResource(t *testing.T) {
+	ns := "default"
+
+	cases := map[string]struct {
+		reason  string
+		rsp     *v1.RunFunctionResponse
+		require func(rsp *v1.RunFunctionResponse)
+		want    *v1.RunFunctionResponse
+	}{
+		"ByName": {
+			reason: "We should require a cluster scoped resource by name.",
+			rsp:    &v1.RunFunctionResponse{},
+			require: func(rsp *v1.RunFunctionResponse) {
+				RequireResource(rsp, "env", "example.org/v1", "Environment", "prod")
+			},
+			want: &v1.RunFunctionResponse{
+				Requirements: &v1.Requirements{
+					Resources: map[string]*v1.ResourceSelector{
+						"env": {ApiVersion: "example.org/v1", Kind: "Environment", Match: &v1.ResourceSelector_MatchName{MatchName: "prod"}},
+					},
+				},
+			},
+		},
+		"ByNameInNamespace": {
+			reason: "We should require a namespaced resource by name.",
+			rsp:    &v1.RunFunctionResponse{},
+			require: func(rsp *v1.RunFunctionResponse) {
+				RequireResource(rsp, "cm", "v1", "ConfigMap", "config").InNamespace("default")
+			},
+			want: &v1.RunFunctionResponse{
+				Requirements: &v1.Requirements{
+					Resources: map[string]*v1.ResourceSelector{
+						"cm": {ApiVersion: "v1", Kind: "ConfigMap", Match: &v1.ResourceSelector_MatchName{MatchName: "config"}, Namespace: &ns},
+					},
+				},
+			},
+		},
+		"ByLabels": {
+			reason: "We should require resources by labels, preserving existing requirements.",
+			rsp: &v1.RunFunctionResponse{
+				Requirements: &v1.Requirements{
+					Schemas: map[string]*v1.SchemaSelector{"xr": {ApiVersion: "example.org/v1", Kind: "XR"}},
+				},
+			},
+			require: func(rsp *v1.RunFunctionResponse) {
+				RequireResources(rsp, "cms", "v1", "ConfigMap", map[string]string{"app": "cool"})
+			},
+			want: &v1.RunFunctionResponse{
+				Requirements: &v1.Requirements{
+					Resources: map[string]*v1.ResourceSelector{
+						"cms": {ApiVersion: "v1", Kind: "ConfigMap", Match: &v1.ResourceSelector_MatchLabels{MatchLabels: &v1.MatchLabels{Labels: map[string]string{"app": "cool"}}}},
+					},
+					Schemas: map[string]*v1.SchemaSelector{"xr": {ApiVersion: "example.org/v1", Kind: "XR"}},
+				},
+			},
+		},
+		"MergeLabels": {
+			reason: "Requiring resources of the same kind by labels again should merge the labels, and keep the namespace.",
+			rsp:    &v1.RunFunctionResponse{},
+			require: func(rsp *v1.RunFunctionResponse) {
+				RequireResources(rsp, "cms", "v1", "ConfigMap", map[string]string{"app": "cool", "tier": "web"}).InNamespace("default")
+				RequireResources(rsp, "cms", "v1", "ConfigMap", map[string]string{"tier": "db"})
+			},
+			want: &v1.RunFunctionResponse{
+				Requirements: &v1.Requirements{
+					Resources: map[string]*v1.ResourceSelector{
+						"cms": {
+							ApiVersion: "v1",
+							Kind:       "ConfigMap",
+							Match:      &v1.ResourceSelector_MatchLabels{MatchLabels: &v1.MatchLabels{Labels: map[string]string{"app": "cool", "tier": "db"}}},
+							Namespace:  &ns,
+						},
+					},
+				},
+			},
+		},
+		"ReplaceDifferentKind": {
+			reason: "Requiring resources of a different kind with the same name should replace the requirement.",
+			rsp:    &v1.RunFunctionResponse{},
+			require: func(rsp *v1.RunFunctionResponse) {
+				RequireResources(rsp, "things", "v1", "ConfigMap", map[string]string{"app": "cool"})
+				RequireResources(rsp, "things", "v1", "Secret", map[string]string{"tier": "db"})
+			},
+			want: &v1.RunFunctionResponse{
+				Requirements: &v1.Requirements{
+					Resources: map[string]*v1.ResourceSelector{
+						"things": {ApiVersion: "v1", Kind: "Secret", Match: &v1.ResourceSelector_MatchLabels{MatchLabels: &v1.MatchLabels{Labels: map[string]string{"tier": "db"}}}},
+					},
+				},
+			},
+		},
+		"ReplaceByName": {
+			reason: "Requiring a resource by name should replace a requirement by labels with the same name.",
+			rsp:    &v1.RunFunctionResponse{},
+			require: func(rsp *v1.RunFunctionResponse) {
+				RequireResources(rsp, "cm", "v1", "ConfigMap", map[string]string{"app": "cool"}).InNamespace("default")
+				RequireResource(rsp, "cm", "v1", "ConfigMap", "config")
+			},
+			want: &v1.RunFunctionResponse{
+				Requirements: &v1.Requirements{
+					Resources: map[string]*v1.ResourceSelector{
+						"cm": {ApiVersion: "v1", Kind: "ConfigMap", Match: &v1.ResourceSelector_MatchName{MatchName: "config"}},
+					},
+				},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			tc.require(tc.rsp)
+			if diff := cmp.Diff(tc.want, tc.rsp, protocmp.Transform()); diff != "" {
+				t.Errorf("\n%s\nRequireResource(...): -want rsp, +got rsp:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestWarnIfRequiredResourcesUnsupported(t *testing.T) {
+	requires := func() *v1.RunFunctionResponse {
+		rsp := &v1.RunFunctionResponse{}
+		RequireResource(rsp, "cm", "v1", "ConfigMap", "config")
+		return rsp
+	}
+
+	type want struct {
+		warned  bool
+		results int
+	}
+
+	cases := map[string]struct {
+		reason string
+		req    *v1.RunFunctionRequest
+		rsp    *v1.RunFunctionResponse
+		want   want
+	}{
+		"NoRequirements": {
+			reason: "We shouldn't warn if the response doesn't require resources.",
+			req:    &v1.RunFunctionRequest{Meta: &v1.RequestMeta{Capabilities: []v1.Capability{v1.Capability_CAPABILITY_CAPABILITIES}}},
+			rsp:    &v1.RunFunctionResponse{},
+			want:   want{warned: false},
+		},
+		"Supported": {
+			reason: "We shouldn't warn if Crossplane advertises required resources.",
+			req: &v1.RunFunctionRequest{Meta: &v1.RequestMeta{Capabilities: []v1.Capability{
+				v1.Capability_CAPABILITY_CAPABILITIES,
+				v1.Capability_CAPABILITY_REQUIRED_RESOURCES,
+			}}},
+			rsp:  requires(),
+			want: want{warned: false},
+		},
+		"NoCapabilities": {
+			reason: "We shouldn't warn if Crossplane predates capability advertisement.",
+			req:    &v1.RunFunctionRequest{},
+			rsp:    requires(),
+			want:   want{warned: false},
+		},
+		"Unsupported": {
+			reason: "We should warn if Crossplane advertises capabilities that don't include required resources.",
+			req:    &v1.RunFunctionRequest{Meta: &v1.RequestMeta{Capabilities: []v1.Capability{v1.Capability_CAPABILITY_CAPABILITIES}}},
+			rsp:    requires(),
+			want:   want{warned: true, results: 1},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := WarnIfRequiredResourcesUnsupported(tc.req, tc.rsp)
+			if diff := cmp.Diff(tc.want.warned, got); diff != "" {
+				t.Errorf("\n%s\nWarnIfRequiredResourcesUnsupported(...): -want, +got:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.results, len(tc.rsp.GetResults())); diff != "" {
+				t.Errorf("\n%s\nWarnIfRequiredResourcesUnsupported(...): -want results, +got results:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
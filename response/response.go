@@ -25,10 +25,12 @@ import (
 	"google.golang.org/protobuf/types/known/durationpb"
 	"google.golang.org/protobuf/types/known/structpb"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
 
 	"github.com/crossplane/function-sdk-go/errors"
 	v1 "github.com/crossplane/function-sdk-go/proto/v1"
 	"github.com/crossplane/function-sdk-go/resource"
+	"github.com/crossplane/function-sdk-go/resource/composed"
 )
 
 // DefaultTTL is the default TTL for which a response can be cached.
@@ -130,6 +132,34 @@ func SetDesiredResources(rsp *v1.RunFunctionResponse, drs map[resource.Name]*uns
 	return nil
 }
 
+// SetDesiredComposedResource sets the named desired composed resource in the
+// supplied response to the supplied object, for example a managed resource
+// type from a provider's Go module. The object is converted using
+// composed.From, so its type must be registered with composed.Scheme. If the
+// response already contains the named resource it's replaced, but its
+// readiness is preserved.
+func SetDesiredComposedResource(rsp *v1.RunFunctionResponse, name resource.Name, o runtime.Object) error {
+	cd, err := composed.From(o)
+	if err != nil {
+		return errors.Wrapf(err, "cannot convert %T to desired composed resource %q", o, name)
+	}
+	s, err := resource.AsStruct(cd)
+	if err != nil {
+		return errors.Wrapf(err, "cannot convert desired composed resource %q to a struct", name)
+	}
+	if rsp.GetDesired() == nil {
+		rsp.Desired = &v1.State{}
+	}
+	if rsp.GetDesired().GetResources() == nil {
+		rsp.Desired.Resources = map[string]*v1.Resource{}
+	}
+	rsp.Desired.Resources[string(name)] = &v1.Resource{
+		Resource: s,
+		Ready:    rsp.GetDesired().GetResources()[string(name)].GetReady(),
+	}
+	return nil
+}
+
 // RequireSchema adds a schema requirement to the response. This tells
 // Crossplane to fetch the OpenAPI schema for the specified resource kind and
 // include it in the next request's required_schemas field. Use
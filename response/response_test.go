@@ -23,10 +23,15 @@ import (
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
 	"google.golang.org/protobuf/testing/protocmp"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
 
 	v1 "github.com/crossplane/function-sdk-go/proto/v1"
 	"github.com/crossplane/function-sdk-go/resource"
+	"github.com/crossplane/function-sdk-go/resource/composed"
 )
 
 func TestSetDesiredResources(t *testing.T) {
@@ -96,6 +101,114 @@ func TestSetDesiredResources(t *testing.T) {
 	}
 }
 
+func TestSetDesiredComposedResource(t *testing.T) {
+	_ = corev1.AddToScheme(composed.Scheme)
+
+	cm := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "cool"},
+		Data:       map[string]string{"cool": "very"},
+	}
+
+	type args struct {
+		rsp  *v1.RunFunctionResponse
+		name resource.Name
+		o    runtime.Object
+	}
+	type want struct {
+		rsp *v1.RunFunctionResponse
+		err error
+	}
+	cases := map[string]struct {
+		reason string
+		args   args
+		want   want
+	}{
+		"Added": {
+			reason: "We should convert the supplied object and add it to the desired state.",
+			args: args{
+				rsp:  &v1.RunFunctionResponse{},
+				name: "cm",
+				o:    cm,
+			},
+			want: want{
+				rsp: &v1.RunFunctionResponse{
+					Desired: &v1.State{
+						Resources: map[string]*v1.Resource{
+							"cm": {
+								Resource: resource.MustStructJSON(`{
+									"apiVersion": "v1",
+									"kind": "ConfigMap",
+									"metadata": {"name": "cool"},
+									"data": {"cool": "very"}
+								}`),
+							},
+						},
+					},
+				},
+			},
+		},
+		"ReplacedPreservingReadiness": {
+			reason: "We should replace an existing desired resource, but preserve its readiness.",
+			args: args{
+				rsp: &v1.RunFunctionResponse{
+					Desired: &v1.State{
+						Resources: map[string]*v1.Resource{
+							"cm": {
+								Resource: resource.MustStructJSON(`{"apiVersion": "v1", "kind": "ConfigMap"}`),
+								Ready:    v1.Ready_READY_TRUE,
+							},
+						},
+					},
+				},
+				name: "cm",
+				o:    cm,
+			},
+			want: want{
+				rsp: &v1.RunFunctionResponse{
+					Desired: &v1.State{
+						Resources: map[string]*v1.Resource{
+							"cm": {
+								Resource: resource.MustStructJSON(`{
+									"apiVersion": "v1",
+									"kind": "ConfigMap",
+									"metadata": {"name": "cool"},
+									"data": {"cool": "very"}
+								}`),
+								Ready: v1.Ready_READY_TRUE,
+							},
+						},
+					},
+				},
+			},
+		},
+		"UnregisteredType": {
+			reason: "We should return an error if the supplied object's type isn't registered with composed.Scheme.",
+			args: args{
+				rsp:  &v1.RunFunctionResponse{},
+				name: "cm",
+				o:    &appsv1.Deployment{},
+			},
+			want: want{
+				rsp: &v1.RunFunctionResponse{},
+				err: cmpopts.AnyError,
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			err := SetDesiredComposedResource(tc.args.rsp, tc.args.name, tc.args.o)
+
+			if diff := cmp.Diff(tc.want.rsp, tc.args.rsp, protocmp.Transform()); diff != "" {
+				t.Errorf("\n%s\nSetDesiredComposedResource(...): -want rsp, +got rsp:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.err, err, cmpopts.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nSetDesiredComposedResource(...): -want err, +got err:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
 func TestOutput(t *testing.T) {
 	type out struct {
 		Cool string `json:"cool"`
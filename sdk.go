@@ -24,13 +24,16 @@ import (
 	"net"
 	"net/http"
 	"os"
+	"os/signal"
 	"path/filepath"
+	"syscall"
 	"time"
 
 	grpcprometheus "github.com/grpc-ecosystem/go-grpc-middleware/providers/prometheus"
 	"github.com/pkg/errors"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"go.opentelemetry.io/otel/trace"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials"
 	ginsecure "google.golang.org/grpc/credentials/insecure"
@@ -49,6 +52,13 @@ const (
 	DefaultAddress        = ":9443"
 	DefaultMaxRecvMsgSize = 1024 * 1024 * 4
 	DefaultMetricsAddress = ":8080"
+
+	// DefaultGracefulShutdownTimeout is how long a Function waits for
+	// in-flight RunFunctionRequests to complete when it's asked to stop. It's
+	// a little shorter than the default Kubernetes termination grace period of
+	// 30 seconds, so that requests are drained before the kubelet kills the
+	// Function's container.
+	DefaultGracefulShutdownTimeout = 25 * time.Second
 )
 
 // ServeOptions configure how a Function is served.
@@ -59,18 +69,61 @@ type ServeOptions struct {
 	Credentials    credentials.TransportCredentials
 	HealthServer   healthgrpc.HealthServer
 
+	// Listener is used instead of listening on Network and Address, if set.
+	Listener net.Listener
+
+	// SocketActivation serves using a listening socket inherited from
+	// systemd, instead of listening on Network and Address.
+	SocketActivation bool
+
+	// Unix domain socket options, used when Network is unix.
+	UnixSocketMode  os.FileMode
+	UnixSocketOwner *UnixSocketOwner
+
 	// Metrics options
 	MetricsAddress    string
 	MetricsRegistry   *prometheus.Registry
 	UnaryInterceptors []grpc.UnaryServerInterceptor
 	MetricsServerOpts []grpcprometheus.ServerMetricsOption
+
+	// GracefulShutdownTimeout is how long to wait for in-flight requests to
+	// complete, and for the metrics server to shut down, when the Function is
+	// asked to stop.
+	GracefulShutdownTimeout time.Duration
+
+	// Logger used by the server itself - e.g. to report certificate reloads.
+	// RunFunction can get a Logger derived from this one, with details of
+	// the request, by calling logging.FromContext.
+	Logger logging.Logger
+
+	// Recovery enables recovering from panics while handling requests.
+	Recovery bool
+
+	// RequestTimeout bounds how long RunFunction may take. Zero means no
+	// timeout.
+	RequestTimeout time.Duration
+
+	// TracerProvider used to trace RunFunction calls. Tracing is disabled
+	// if this is nil.
+	TracerProvider trace.TracerProvider
+
+	// certificates are periodically reloaded while the Function is served.
+	certificates *certificateReloader
+
+	// concurrencyLimit bounds the number of concurrent RunFunctionRequests.
+	// Zero means no limit.
+	concurrencyLimit int
+
+	// queueTimeout bounds how long a RunFunctionRequest waits for the
+	// concurrency limit.
+	queueTimeout time.Duration
 }
 
 // A ServeOption configures how a Function is served.
 type ServeOption func(o *ServeOptions) error
 
-// Listen configures the network, address, and maximum message size on which the
-// Function will listen for RunFunctionRequests.
+// Listen configures the network and address on which the Function will listen
+// for RunFunctionRequests. Use ListenUnix to listen on a Unix domain socket.
 func Listen(network, address string) ServeOption {
 	return func(o *ServeOptions) error {
 		o.Network = network
@@ -82,6 +135,8 @@ func Listen(network, address string) ServeOption {
 // MTLSCertificates specifies a directory from which to load mTLS certificates.
 // The directory must contain the server certificate (tls.key and tls.crt), as
 // well as a CA certificate (ca.crt) that will be used to authenticate clients.
+// The certificates are loaded once. Use ReloadingMTLSCertificates to pick up
+// rotated certificates without restarting the Function.
 func MTLSCertificates(dir string) ServeOption {
 	return func(o *ServeOptions) error {
 		if dir == "" {
@@ -116,6 +171,7 @@ func MTLSCertificates(dir string) ServeOption {
 			ClientCAs:    pool,
 			ClientAuth:   tls.RequireAndVerifyClientCert,
 		})
+		o.certificates = nil
 
 		return nil
 	}
@@ -128,6 +184,7 @@ func Insecure(insecure bool) ServeOption {
 	return func(o *ServeOptions) error {
 		if insecure {
 			o.Credentials = ginsecure.NewCredentials()
+			o.certificates = nil
 		}
 		return nil
 	}
@@ -183,16 +240,63 @@ func WithMetricsServerOpts(opts ...grpcprometheus.ServerMetricsOption) ServeOpti
 	}
 }
 
+// WithLogger configures the Logger the server uses to report events that
+// happen outside of any RunFunctionRequest, such as certificate reloads. By
+// default the server only logs certificate reloads and panics recovered by
+// WithRecovery, to stderr.
+//
+// The context passed to RunFunction carries a Logger derived from this one
+// that includes the request's tag, a unique request ID, and the observed
+// composite resource's apiVersion, kind, name, and namespace. Use
+// logging.FromContext to retrieve it.
+func WithLogger(log logging.Logger) ServeOption {
+	return func(o *ServeOptions) error {
+		o.Logger = log
+		return nil
+	}
+}
+
+// WithGracefulShutdownTimeout configures how long the Function waits for
+// in-flight RunFunctionRequests to complete when it's asked to stop. Requests
+// that are still running when the timeout expires are cancelled. The timeout
+// bounds the whole shutdown, including stopping the metrics server. A second
+// SIGTERM or interrupt while the Function is stopping terminates it
+// immediately.
+func WithGracefulShutdownTimeout(d time.Duration) ServeOption {
+	return func(o *ServeOptions) error {
+		if d < 0 {
+			return errors.New("graceful shutdown timeout must not be negative")
+		}
+		o.GracefulShutdownTimeout = d
+		return nil
+	}
+}
+
 // Serve the supplied Function by creating a gRPC server and listening for
-// RunFunctionRequests. Blocks until the server returns an error.
+// RunFunctionRequests. Blocks until the server returns an error, or until the
+// process receives SIGTERM or SIGINT. See ServeContext.
 func Serve(fn v1.FunctionRunnerServiceServer, o ...ServeOption) error {
+	return ServeContext(context.Background(), fn, o...)
+}
+
+// ServeContext serves the supplied Function by creating a gRPC server and
+// listening for RunFunctionRequests. Blocks until the supplied context is
+// done, the process receives SIGTERM or SIGINT, or either the gRPC or metrics
+// server returns an error.
+//
+// When the context is done or a signal is received ServeContext stops
+// accepting new connections and waits up to the graceful shutdown timeout for
+// in-flight RunFunctionRequests to complete, then shuts down the metrics
+// server. It returns nil if it stopped because it was asked to.
+func ServeContext(ctx context.Context, fn v1.FunctionRunnerServiceServer, o ...ServeOption) error {
 	//nolint:forcetypeassert // prometheus.DefaultRegisterer is always *prometheus.Registry
 	so := &ServeOptions{
-		Network:         DefaultNetwork,
-		Address:         DefaultAddress,
-		MaxRecvMsgSize:  DefaultMaxRecvMsgSize,
-		MetricsAddress:  DefaultMetricsAddress,
-		MetricsRegistry: prometheus.DefaultRegisterer.(*prometheus.Registry), // Use default registry
+		Network:                 DefaultNetwork,
+		Address:                 DefaultAddress,
+		MaxRecvMsgSize:          DefaultMaxRecvMsgSize,
+		MetricsAddress:          DefaultMetricsAddress,
+		MetricsRegistry:         prometheus.DefaultRegisterer.(*prometheus.Registry), // Use default registry
+		GracefulShutdownTimeout: DefaultGracefulShutdownTimeout,
 	}
 
 	for _, fn := range o {
@@ -201,14 +305,29 @@ func Serve(fn v1.FunctionRunnerServiceServer, o ...ServeOption) error {
 		}
 	}
 
+	// Problems that need an operator's attention, like recovered panics and
+	// certificate reload failures, are logged even if we weren't supplied a
+	// Logger.
+	alert := so.Logger
+	if so.Logger == nil {
+		log, err := logging.NewLogger(false)
+		if err != nil {
+			return errors.Wrap(err, "cannot create default logger")
+		}
+		so.Logger = logging.NewNopLogger()
+		alert = log
+	}
+
 	if so.Credentials == nil {
 		return errors.New("no credentials provided - did you specify the Insecure or MTLSCertificates options?")
 	}
 
-	listenConfig := &net.ListenConfig{}
-	lis, err := listenConfig.Listen(context.Background(), so.Network, so.Address)
+	ctx, stop := signal.NotifyContext(ctx, syscall.SIGTERM, os.Interrupt)
+	defer stop()
+
+	lis, err := listen(ctx, so)
 	if err != nil {
-		return errors.Wrapf(err, "cannot listen for %s connections at address %q", so.Network, so.Address)
+		return err
 	}
 
 	// Create server options
@@ -217,22 +336,63 @@ func Serve(fn v1.FunctionRunnerServiceServer, o ...ServeOption) error {
 		grpc.Creds(so.Credentials),
 	}
 
-	// Build interceptors based on options
+	// Build interceptors based on options. The first interceptor is the
+	// outermost, so metrics observe the status codes produced by recovery.
 	var interceptors []grpc.UnaryServerInterceptor
 	var metrics *grpcprometheus.ServerMetrics
 
+	// Each call to ServeContext gets its own limiter, so servers never share
+	// concurrency slots or metrics.
+	var limiter *concurrencyLimiter
+	if so.concurrencyLimit > 0 {
+		limiter = newConcurrencyLimiter(so.concurrencyLimit, so.queueTimeout)
+	}
+
 	// Add metrics interceptor if metrics address is provided
 	if so.MetricsAddress != "" {
 		// Use Prometheus metrics
 		metrics = grpcprometheus.NewServerMetrics(so.MetricsServerOpts...)
+		rspMetrics := newResponseMetrics()
+		interceptors = append(interceptors, metrics.UnaryServerInterceptor(), rspMetrics.Interceptor())
 
-		// Apply metrics interceptor and custom interceptors
-		interceptors = append(interceptors, metrics.UnaryServerInterceptor())
-		interceptors = append(interceptors, so.UnaryInterceptors...)
-		serverOpts = append(serverOpts, grpc.ChainUnaryInterceptor(interceptors...))
 		// Register the metrics with the registry
-		so.MetricsRegistry.MustRegister(metrics)
+		so.MetricsRegistry.MustRegister(metrics, rspMetrics)
+
+		if so.certificates != nil {
+			so.MetricsRegistry.MustRegister(so.certificates)
+		}
+
+		if limiter != nil {
+			so.MetricsRegistry.MustRegister(limiter)
+		}
 	}
+
+	// Make a request scoped logger available to RunFunction.
+	interceptors = append(interceptors, loggingInterceptor(so.Logger))
+
+	if so.Recovery {
+		interceptors = append(interceptors, recoveryInterceptor(alert))
+	}
+
+	if so.TracerProvider != nil {
+		serverOpts = append(serverOpts, grpc.StatsHandler(tracingStatsHandler(so.TracerProvider)))
+		interceptors = append(interceptors, tracingInterceptor())
+	}
+
+	// The timeout comes before the limiter so that it bounds how long a
+	// request waits for a concurrency slot, too.
+	if so.RequestTimeout > 0 {
+		interceptors = append(interceptors, timeoutInterceptor(so.RequestTimeout))
+	}
+
+	if limiter != nil {
+		interceptors = append(interceptors, limiter.Interceptor())
+	}
+
+	// Apply custom interceptors
+	interceptors = append(interceptors, so.UnaryInterceptors...)
+	serverOpts = append(serverOpts, grpc.ChainUnaryInterceptor(interceptors...))
+
 	srv := grpc.NewServer(serverOpts...)
 	reflection.Register(srv)
 	v1.RegisterFunctionRunnerServiceServer(srv, fn)
@@ -242,16 +402,31 @@ func Serve(fn v1.FunctionRunnerServiceServer, o ...ServeOption) error {
 		healthgrpc.RegisterHealthServer(srv, so.HealthServer)
 	}
 
+	// Both servers report their result here. The channel is buffered so
+	// neither goroutine blocks if we've already returned.
+	errs := make(chan error, 2)
+
 	// Start metrics server if address is provided
+	var metricsServer *http.Server
 	if so.MetricsAddress != "" {
 		// Initialize metrics for the gRPC server
 		if metrics != nil {
 			metrics.InitializeMetrics(srv)
 		}
+
+		// Listen before we start serving so that we return an error
+		// immediately if we can't bind to the metrics address.
+		listenConfig := &net.ListenConfig{}
+		mlis, err := listenConfig.Listen(ctx, "tcp", so.MetricsAddress)
+		if err != nil {
+			_ = lis.Close()
+			return errors.Wrapf(err, "cannot listen for metrics connections at address %q", so.MetricsAddress)
+		}
+
 		// Use the registry for metrics handler
 		handler := promhttp.HandlerFor(so.MetricsRegistry, promhttp.HandlerOpts{})
 
-		metricsServer := &http.Server{
+		metricsServer = &http.Server{
 			Addr:              so.MetricsAddress,
 			Handler:           handler,
 			ReadHeaderTimeout: 30 * time.Second,
@@ -259,16 +434,78 @@ func Serve(fn v1.FunctionRunnerServiceServer, o ...ServeOption) error {
 
 		// Start metrics server in a goroutine
 		go func() {
-			_ = metricsServer.ListenAndServe() // Ignore errors
+			if err := metricsServer.Serve(mlis); !errors.Is(err, http.ErrServerClosed) {
+				errs <- errors.Wrap(err, "cannot serve metrics")
+			}
 		}()
 	}
 
-	return errors.Wrap(srv.Serve(lis), "cannot serve mTLS gRPC connections")
+	if so.certificates != nil {
+		go so.certificates.Run(ctx, alert)
+	}
+
+	go func() {
+		errs <- errors.Wrap(srv.Serve(lis), "cannot serve mTLS gRPC connections")
+	}()
+
+	select {
+	case <-ctx.Done():
+		// Stop relaying signals, so that a second signal terminates the
+		// process rather than being swallowed while we drain.
+		stop()
+
+		// We were asked to stop. Drain in-flight requests, then stop the
+		// metrics server so it can report on them until the very end. Both
+		// share one deadline, so shutdown takes at most the timeout.
+		sctx, cancel := context.WithTimeout(context.Background(), so.GracefulShutdownTimeout)
+		defer cancel()
+		stopGracefully(sctx, srv)
+		return shutdownMetrics(sctx, metricsServer)
+	case err := <-errs:
+		// One of our servers failed. Don't bother waiting for requests to
+		// drain; we're not going to be healthy anyway.
+		srv.Stop()
+		if metricsServer != nil {
+			_ = metricsServer.Close()
+		}
+		return err
+	}
+}
+
+// stopGracefully stops the supplied gRPC server from accepting new connections
+// and waits for in-flight requests to complete. If they don't complete before
+// the supplied context is done it stops the server forcefully, cancelling them.
+func stopGracefully(ctx context.Context, srv *grpc.Server) {
+	done := make(chan struct{})
+	go func() {
+		srv.GracefulStop()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-ctx.Done():
+		srv.Stop()
+	}
+}
+
+// shutdownMetrics shuts down the supplied metrics server, if any, waiting until
+// the supplied context is done for active connections to become idle.
+func shutdownMetrics(ctx context.Context, srv *http.Server) error {
+	if srv == nil {
+		return nil
+	}
+	if err := srv.Shutdown(ctx); err != nil {
+		_ = srv.Close()
+		return errors.Wrap(err, "cannot shut down metrics server")
+	}
+	return nil
 }
 
-// NewLogger returns a new logger.
-func NewLogger(debug bool) (logging.Logger, error) {
-	return logging.NewLogger(debug)
+// NewLogger returns a new logger. See logging.NewLogger for details of the
+// supplied options.
+func NewLogger(debug bool, o ...logging.Option) (logging.Logger, error) {
+	return logging.NewLogger(debug, o...)
 }
 
 // A BetaServer is a v1beta1 FunctionRunnerServiceServer that wraps an identical
@@ -25,6 +25,7 @@ import (
 	"net"
 	"net/http"
 	"strings"
+	"sync"
 	"testing"
 	"time"
 
@@ -458,6 +459,189 @@ func TestMetricsServer_WithCustomMetricsServerOpts(t *testing.T) {
 	})
 }
 
+// TestServeContext_GracefulShutdown verifies that in-flight requests complete
+// when the context passed to ServeContext is cancelled.
+func TestServeContext_GracefulShutdown(t *testing.T) {
+	started := make(chan struct{})
+	mockServer := &BlockingFunctionServer{
+		started: started,
+		wait:    500 * time.Millisecond,
+		rsp: &v1.RunFunctionResponse{
+			Meta: &v1.ResponseMeta{Tag: "graceful"},
+		},
+	}
+
+	grpcPort := getAvailablePort(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	serverDone := make(chan error, 1)
+	go func() {
+		serverDone <- ServeContext(ctx, mockServer,
+			Listen("tcp", fmt.Sprintf(":%d", grpcPort)),
+			Insecure(true),
+			WithMetricsServer(""),
+			WithGracefulShutdownTimeout(10*time.Second),
+		)
+	}()
+
+	conn, err := grpc.NewClient(fmt.Sprintf("localhost:%d", grpcPort),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatalf("Failed to connect: %v", err)
+	}
+	defer conn.Close()
+
+	client := v1.NewFunctionRunnerServiceClient(conn)
+
+	type result struct {
+		rsp *v1.RunFunctionResponse
+		err error
+	}
+	done := make(chan result, 1)
+	go func() {
+		rsp, err := client.RunFunction(context.Background(), &v1.RunFunctionRequest{}, grpc.WaitForReady(true))
+		done <- result{rsp: rsp, err: err}
+	}()
+
+	// Stop the server while the request is in flight.
+	<-started
+	cancel()
+
+	r := <-done
+	if r.err != nil {
+		t.Errorf("RunFunction(...): in-flight request failed during graceful shutdown: %v", r.err)
+	}
+	if diff := cmp.Diff(mockServer.rsp, r.rsp, protocmp.Transform()); diff != "" {
+		t.Errorf("RunFunction(...): -want rsp, +got rsp:\n%s", diff)
+	}
+
+	select {
+	case err := <-serverDone:
+		if err != nil {
+			t.Errorf("ServeContext(...): want nil error after cancellation, got %v", err)
+		}
+	case <-time.After(10 * time.Second):
+		t.Error("ServeContext(...): did not return after context was cancelled")
+	}
+}
+
+// TestServeContext_ShutdownDeadline verifies that draining in-flight requests
+// and shutting down the metrics server share one graceful shutdown timeout.
+func TestServeContext_ShutdownDeadline(t *testing.T) {
+	started := make(chan struct{})
+	fn := &BlockingFunctionServer{
+		started: started,
+		wait:    5 * time.Second,
+		rsp:     &v1.RunFunctionResponse{},
+	}
+
+	grpcPort := getAvailablePort(t)
+	metricsPort := getAvailablePort(t)
+	timeout := 500 * time.Millisecond
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	serverDone := make(chan error, 1)
+	go func() {
+		serverDone <- ServeContext(ctx, fn,
+			Listen("tcp", fmt.Sprintf(":%d", grpcPort)),
+			Insecure(true),
+			WithMetricsServer(fmt.Sprintf(":%d", metricsPort)),
+			WithMetricsRegistry(prometheus.NewRegistry()),
+			WithGracefulShutdownTimeout(timeout),
+		)
+	}()
+
+	conn, err := grpc.NewClient(fmt.Sprintf("localhost:%d", grpcPort),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatalf("Failed to connect: %v", err)
+	}
+	defer conn.Close()
+
+	// A request that outlives the graceful shutdown timeout.
+	go func() {
+		_, _ = v1.NewFunctionRunnerServiceClient(conn).RunFunction(context.Background(), &v1.RunFunctionRequest{}, grpc.WaitForReady(true))
+	}()
+	<-started
+
+	// A metrics connection that's never idle, because it never finishes
+	// sending its request.
+	dialer := &net.Dialer{}
+	mconn, err := dialer.DialContext(context.Background(), "tcp", fmt.Sprintf("localhost:%d", metricsPort))
+	if err != nil {
+		t.Fatalf("Failed to connect to metrics server: %v", err)
+	}
+	defer mconn.Close()
+	if _, err := mconn.Write([]byte("GET /metrics HTTP/1.1\r\n")); err != nil {
+		t.Fatalf("Failed to write to metrics server: %v", err)
+	}
+
+	begin := time.Now()
+	cancel()
+
+	select {
+	case <-serverDone:
+		// Allow some slack, but less than two timeouts back to back.
+		if elapsed := time.Since(begin); elapsed > timeout*3/2 {
+			t.Errorf("ServeContext(...): shutdown took %s, want about %s", elapsed, timeout)
+		}
+	case <-time.After(10 * time.Second):
+		t.Error("ServeContext(...): did not return after context was cancelled")
+	}
+}
+
+// TestServeContext_MetricsListenError verifies that ServeContext returns an
+// error if the metrics server can't listen, rather than ignoring it.
+func TestServeContext_MetricsListenError(t *testing.T) {
+	listenConfig := &net.ListenConfig{}
+	taken, err := listenConfig.Listen(context.Background(), "tcp", "localhost:0")
+	if err != nil {
+		t.Fatalf("Failed to listen: %v", err)
+	}
+	defer taken.Close()
+
+	serverDone := make(chan error, 1)
+	go func() {
+		serverDone <- ServeContext(context.Background(), &MockFunctionServer{},
+			Listen("tcp", fmt.Sprintf(":%d", getAvailablePort(t))),
+			Insecure(true),
+			WithMetricsServer(taken.Addr().String()),
+			WithMetricsRegistry(prometheus.NewRegistry()),
+		)
+	}()
+
+	select {
+	case err := <-serverDone:
+		if err == nil {
+			t.Error("ServeContext(...): want error when metrics address is in use, got nil")
+		}
+	case <-time.After(10 * time.Second):
+		t.Error("ServeContext(...): did not return an error when metrics address is in use")
+	}
+}
+
+// BlockingFunctionServer signals that a RunFunctionRequest started, then waits
+// before returning its response.
+type BlockingFunctionServer struct {
+	v1.UnimplementedFunctionRunnerServiceServer
+
+	started chan struct{}
+	wait    time.Duration
+	rsp     *v1.RunFunctionResponse
+
+	once sync.Once
+}
+
+func (s *BlockingFunctionServer) RunFunction(context.Context, *v1.RunFunctionRequest) (*v1.RunFunctionResponse, error) {
+	s.once.Do(func() { close(s.started) })
+	time.Sleep(s.wait)
+	return s.rsp, nil
+}
+
 // Helper function to get an available port.
 func getAvailablePort(t *testing.T) int {
 	t.Helper()
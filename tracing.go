@@ -0,0 +1,115 @@
+/*
+Copyright 2026 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package function
+
+import (
+	"context"
+
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/stats"
+	"google.golang.org/protobuf/types/known/structpb"
+
+	v1 "github.com/crossplane/function-sdk-go/proto/v1"
+	"github.com/crossplane/function-sdk-go/proto/v1beta1"
+	"github.com/crossplane/function-sdk-go/tracing"
+)
+
+// WithTracing configures the Function to trace RunFunctionRequests using the
+// supplied OpenTelemetry TracerProvider. Trace context is propagated from the
+// caller using the globally configured propagator.
+//
+// Each RunFunction call gets a server span, annotated with the request's tag,
+// the observed composite resource's GVK and name, and the severities of the
+// results the Function returned. The span is available to the Function via the
+// context passed to RunFunction. Use tracing.Start to create child spans.
+func WithTracing(tp trace.TracerProvider) ServeOption {
+	return func(o *ServeOptions) error {
+		o.TracerProvider = tp
+		return nil
+	}
+}
+
+// tracingStatsHandler returns a gRPC stats handler that creates a span for
+// each RunFunction call.
+func tracingStatsHandler(tp trace.TracerProvider) stats.Handler {
+	return otelgrpc.NewServerHandler(
+		otelgrpc.WithTracerProvider(tp),
+		otelgrpc.WithFilter(func(i *stats.RPCTagInfo) bool { return isRunFunction(i.FullMethodName) }),
+	)
+}
+
+// tracingInterceptor returns an interceptor that annotates the span created by
+// the tracing stats handler with details of the request and response.
+func tracingInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		span := trace.SpanFromContext(ctx)
+		if !isRunFunction(info.FullMethod) || !span.IsRecording() {
+			return handler(ctx, req)
+		}
+
+		if tag, xr, ok := requestMeta(req); ok {
+			span.SetAttributes(requestAttributes(tag, xr)...)
+		}
+
+		rsp, err := handler(ctx, req)
+
+		var severities []string
+		switch r := rsp.(type) {
+		case *v1.RunFunctionResponse:
+			for _, rs := range r.GetResults() {
+				severities = append(severities, rs.GetSeverity().String())
+				if rs.GetSeverity() == v1.Severity_SEVERITY_FATAL {
+					span.SetStatus(codes.Error, rs.GetMessage())
+				}
+			}
+		case *v1beta1.RunFunctionResponse:
+			for _, rs := range r.GetResults() {
+				severities = append(severities, rs.GetSeverity().String())
+				if rs.GetSeverity() == v1beta1.Severity_SEVERITY_FATAL {
+					span.SetStatus(codes.Error, rs.GetMessage())
+				}
+			}
+		}
+		if len(severities) > 0 {
+			span.SetAttributes(tracing.KeyResultSeverities.StringSlice(severities))
+		}
+
+		return rsp, err
+	}
+}
+
+// requestAttributes returns span attributes describing a RunFunctionRequest
+// with the supplied tag and observed composite resource.
+func requestAttributes(tag string, xr *structpb.Struct) []attribute.KeyValue {
+	f := xr.GetFields()
+	md := f["metadata"].GetStructValue().GetFields()
+
+	attrs := []attribute.KeyValue{
+		tracing.KeyTag.String(tag),
+		tracing.KeyCompositeAPIVersion.String(f["apiVersion"].GetStringValue()),
+		tracing.KeyCompositeKind.String(f["kind"].GetStringValue()),
+		tracing.KeyCompositeName.String(md["name"].GetStringValue()),
+	}
+	if ns := md["namespace"].GetStringValue(); ns != "" {
+		attrs = append(attrs, tracing.KeyCompositeNamespace.String(ns))
+	}
+	return attrs
+}
@@ -0,0 +1,70 @@
+/*
+Copyright 2026 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package tracing contains utilities for tracing Functions using
+// OpenTelemetry.
+//
+// When a Function is served using function.WithTracing, each RunFunction call
+// is traced. The span is available from the context passed to RunFunction. Use
+// Start to create child spans that are part of the same trace.
+package tracing
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// TracerName is the name of the tracer used by Start.
+const TracerName = "github.com/crossplane/function-sdk-go"
+
+// Well-known span attribute keys.
+const (
+	// KeyTag is the tag of the RunFunctionRequest.
+	KeyTag = attribute.Key("crossplane.function.tag")
+
+	// KeyCompositeAPIVersion is the apiVersion of the observed composite
+	// resource (XR).
+	KeyCompositeAPIVersion = attribute.Key("crossplane.composite.api_version")
+
+	// KeyCompositeKind is the kind of the observed XR.
+	KeyCompositeKind = attribute.Key("crossplane.composite.kind")
+
+	// KeyCompositeName is the name of the observed XR.
+	KeyCompositeName = attribute.Key("crossplane.composite.name")
+
+	// KeyCompositeNamespace is the namespace of the observed XR, if any.
+	KeyCompositeNamespace = attribute.Key("crossplane.composite.namespace")
+
+	// KeyResultSeverities are the severities of the results returned in the
+	// RunFunctionResponse, in order.
+	KeyResultSeverities = attribute.Key("crossplane.function.result_severities")
+)
+
+// SpanFromContext returns the span from the supplied context. It returns a
+// no-op span if the context doesn't contain a span, for example because the
+// Function isn't served with tracing enabled.
+func SpanFromContext(ctx context.Context) trace.Span {
+	return trace.SpanFromContext(ctx)
+}
+
+// Start a span that is a child of the span in the supplied context. The child
+// span is created by the same TracerProvider as its parent, so Functions don't
+// need access to the TracerProvider passed to function.WithTracing.
+func Start(ctx context.Context, name string, o ...trace.SpanStartOption) (context.Context, trace.Span) {
+	return trace.SpanFromContext(ctx).TracerProvider().Tracer(TracerName).Start(ctx, name, o...)
+}
@@ -0,0 +1,32 @@
+/*
+Copyright 2026 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package tracingtest contains utilities for testing Functions that are traced
+// using OpenTelemetry.
+package tracingtest
+
+import (
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+// NewInMemoryTracerProvider returns a TracerProvider that synchronously exports
+// spans to the returned in-memory exporter, so tests can make assertions about
+// spans without a collector.
+func NewInMemoryTracerProvider() (*sdktrace.TracerProvider, *tracetest.InMemoryExporter) {
+	e := tracetest.NewInMemoryExporter()
+	return sdktrace.NewTracerProvider(sdktrace.WithSyncer(e)), e
+}
@@ -0,0 +1,140 @@
+/*
+Copyright 2026 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package function
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"google.golang.org/grpc"
+
+	v1 "github.com/crossplane/function-sdk-go/proto/v1"
+	"github.com/crossplane/function-sdk-go/resource"
+	"github.com/crossplane/function-sdk-go/tracing"
+	"github.com/crossplane/function-sdk-go/tracing/tracingtest"
+)
+
+func TestTracingInterceptor(t *testing.T) {
+	type want struct {
+		attrs  []attribute.KeyValue
+		status codes.Code
+		// Names of the spans exported, in the order they ended.
+		spans []string
+	}
+
+	cases := map[string]struct {
+		reason string
+		req    *v1.RunFunctionRequest
+		rsp    *v1.RunFunctionResponse
+		want   want
+	}{
+		"AnnotateSpan": {
+			reason: "The span should be annotated with the request tag, XR, and result severities.",
+			req: &v1.RunFunctionRequest{
+				Meta: &v1.RequestMeta{Tag: "hi"},
+				Observed: &v1.State{
+					Composite: &v1.Resource{
+						Resource: resource.MustStructJSON(`{
+							"apiVersion": "example.org/v1",
+							"kind": "XR",
+							"metadata": {"name": "cool-xr", "namespace": "default"}
+						}`),
+					},
+				},
+			},
+			rsp: &v1.RunFunctionResponse{
+				Results: []*v1.Result{
+					{Severity: v1.Severity_SEVERITY_NORMAL},
+					{Severity: v1.Severity_SEVERITY_WARNING},
+				},
+			},
+			want: want{
+				attrs: []attribute.KeyValue{
+					tracing.KeyTag.String("hi"),
+					tracing.KeyCompositeAPIVersion.String("example.org/v1"),
+					tracing.KeyCompositeKind.String("XR"),
+					tracing.KeyCompositeName.String("cool-xr"),
+					tracing.KeyCompositeNamespace.String("default"),
+					tracing.KeyResultSeverities.StringSlice([]string{"SEVERITY_NORMAL", "SEVERITY_WARNING"}),
+				},
+				status: codes.Unset,
+				spans:  []string{"child", "RunFunction"},
+			},
+		},
+		"FatalResult": {
+			reason: "The span should have an error status if the Function returns a fatal result.",
+			req:    &v1.RunFunctionRequest{Meta: &v1.RequestMeta{Tag: "hi"}},
+			rsp: &v1.RunFunctionResponse{
+				Results: []*v1.Result{
+					{Severity: v1.Severity_SEVERITY_FATAL, Message: "boom"},
+				},
+			},
+			want: want{
+				attrs: []attribute.KeyValue{
+					tracing.KeyTag.String("hi"),
+					tracing.KeyCompositeAPIVersion.String(""),
+					tracing.KeyCompositeKind.String(""),
+					tracing.KeyCompositeName.String(""),
+					tracing.KeyResultSeverities.StringSlice([]string{"SEVERITY_FATAL"}),
+				},
+				status: codes.Error,
+				spans:  []string{"child", "RunFunction"},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			tp, e := tracingtest.NewInMemoryTracerProvider()
+			ctx, span := tp.Tracer("test").Start(context.Background(), "RunFunction")
+
+			i := tracingInterceptor()
+			info := &grpc.UnaryServerInfo{FullMethod: v1.FunctionRunnerService_RunFunction_FullMethodName}
+			_, _ = i(ctx, tc.req, info, func(ctx context.Context, _ any) (any, error) {
+				// Functions should be able to create child spans.
+				_, child := tracing.Start(ctx, "child")
+				child.End()
+				return tc.rsp, nil
+			})
+			span.End()
+
+			spans := e.GetSpans()
+			names := make([]string, 0, len(spans))
+			for _, s := range spans {
+				names = append(names, s.Name)
+			}
+			if diff := cmp.Diff(tc.want.spans, names); diff != "" {
+				t.Fatalf("\n%s\ni(...): -want spans, +got spans:\n%s", tc.reason, diff)
+			}
+
+			got := spans[len(spans)-1]
+			if diff := cmp.Diff(tc.want.attrs, got.Attributes, cmpopts.EquateComparable(attribute.Value{})); diff != "" {
+				t.Errorf("\n%s\ni(...): -want attributes, +got attributes:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.status, got.Status.Code); diff != "" {
+				t.Errorf("\n%s\ni(...): -want status, +got status:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(got.SpanContext.SpanID(), spans[0].Parent.SpanID()); diff != "" {
+				t.Errorf("\n%s\ni(...): -want child span parent, +got child span parent:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}